@@ -0,0 +1,95 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AwardSpec defines the desired state of Award.
+type AwardSpec struct {
+	// CTFdName is the name of the CTFd instance the award is created in. The CTFd instance needs to be located in the
+	// same namespace as the award.
+	// +kubebuilder:validation:Required
+	CTFdName string `json:"ctfdName"`
+
+	// ParticipantName is the name of the Participant resource which receives the award. The participant needs to be
+	// located in the same namespace as the award. Exactly one of ParticipantName and TeamName needs to be set.
+	// +kubebuilder:validation:Optional
+	ParticipantName string `json:"participantName,omitempty"`
+
+	// TeamName is the name of the Team resource which receives the award. The team needs to be located in the same
+	// namespace as the award. Exactly one of ParticipantName and TeamName needs to be set.
+	// +kubebuilder:validation:Optional
+	TeamName string `json:"teamName,omitempty"`
+
+	// Name is the name of the award as shown on the scoreboard.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Description is the reason for the award. It is only visible to admins.
+	// +kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+
+	// Value is the number of points added to the score. Negative values deduct points.
+	// +kubebuilder:validation:Required
+	Value int `json:"value"`
+
+	// Category is the category of the award as shown on the scoreboard.
+	// +kubebuilder:validation:Optional
+	Category string `json:"category,omitempty"`
+
+	// Icon is the name of the icon CTFd shows for the award.
+	// +kubebuilder:validation:Optional
+	Icon string `json:"icon,omitempty"`
+}
+
+// AwardStatus defines the observed state of Award.
+type AwardStatus struct {
+	// ObservedGeneration is the generation of the resource which was last reconciled.
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Id is the database id of the award in CTFd. It is zero as long as the award was not created in CTFd.
+	// +kubebuilder:validation:Optional
+	Id int `json:"id,omitempty"`
+
+	// Conditions provide details about the current state of the resource.
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="CTFd",type="string",JSONPath=".spec.ctfdName"
+// +kubebuilder:printcolumn:name="Name",type="string",JSONPath=".spec.name"
+// +kubebuilder:printcolumn:name="Value",type="integer",JSONPath=".spec.value"
+// +kubebuilder:printcolumn:name="Participant",type="string",JSONPath=".spec.participantName"
+// +kubebuilder:printcolumn:name="Team",type="string",JSONPath=".spec.teamName"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Award is the Schema for the awards API.
+type Award struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AwardSpec   `json:"spec,omitempty"`
+	Status AwardStatus `json:"status,omitempty"`
+}
+
+func (r *Award) GetConditions() *[]metav1.Condition {
+	return &r.Status.Conditions
+}
+
+// +kubebuilder:object:root=true
+
+// AwardList contains a list of Award.
+type AwardList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Award `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Award{}, &AwardList{})
+}
@@ -0,0 +1,159 @@
+package v1alpha1
+
+// ChallengeAnnotationPrefix is the prefix shared by all annotations on ChallengeDescription resources which are
+// interpreted by this operator.
+const ChallengeAnnotationPrefix = "ui.ctf.backbone81/"
+
+// These are the annotations on ChallengeDescription resources which configure challenges in CTFd beyond what the
+// ChallengeDescription itself provides.
+const (
+	// ChallengeFlagsAnnotation provides additional flags for the challenge as a JSON list of ChallengeFlag. The flag
+	// from the ChallengeDescription is always accepted as a static case-sensitive flag.
+	ChallengeFlagsAnnotation = ChallengeAnnotationPrefix + "flags"
+
+	// ChallengeDynamicScoringAnnotation turns the challenge into a dynamic challenge. The content is a JSON encoded
+	// ChallengeDynamicScoring. Changing between standard and dynamic challenges recreates the challenge in CTFd, which
+	// loses all solves of the challenge.
+	ChallengeDynamicScoringAnnotation = ChallengeAnnotationPrefix + "dynamic-scoring"
+
+	// ChallengeStateAnnotation is either visible or hidden. If not set, the state is left unchanged.
+	ChallengeStateAnnotation = ChallengeAnnotationPrefix + "state"
+
+	// ChallengeMaxAttemptsAnnotation is the number of attempts a player has for solving the challenge. Zero allows for
+	// unlimited attempts. If not set, the max attempts are left unchanged.
+	ChallengeMaxAttemptsAnnotation = ChallengeAnnotationPrefix + "max-attempts"
+
+	// ChallengeAttributionAnnotation is the author of the challenge. If not set, the attribution is left unchanged.
+	ChallengeAttributionAnnotation = ChallengeAnnotationPrefix + "attribution"
+
+	// ChallengeConnectionInfoAnnotation tells players how to connect to the challenge. If not set, the connection info
+	// is left unchanged.
+	ChallengeConnectionInfoAnnotation = ChallengeAnnotationPrefix + "connection-info"
+
+	// ChallengeConnectionInfoTemplateAnnotation is a Go template for the connection info, which is rendered with the
+	// endpoint of the oldest ChallengeInstance of the ChallengeDescription, like "nc {{.Host}} {{.Port}}". The endpoint
+	// is taken from the first Ingress or from the first Service of type LoadBalancer in the namespace of the
+	// ChallengeInstance. The connection info is updated whenever the endpoint changes. Until an endpoint is available,
+	// the connection info annotation is used instead.
+	ChallengeConnectionInfoTemplateAnnotation = ChallengeAnnotationPrefix + "connection-info-template"
+
+	// ChallengeReleaseTimeAnnotation is the point in time in RFC 3339 format when the challenge is released. Until
+	// then, the challenge is hidden regardless of the state annotation. Afterward, the challenge is visible unless the
+	// state annotation says otherwise.
+	ChallengeReleaseTimeAnnotation = ChallengeAnnotationPrefix + "release-time"
+
+	// ChallengePrerequisitesAnnotation is a comma separated list of ChallengeDescriptions which need to be solved
+	// before the challenge is unlocked. ChallengeDescriptions from other namespaces are referenced as namespace/name.
+	// The referenced ChallengeDescriptions need to be reconciled into the same CTFd instance.
+	ChallengePrerequisitesAnnotation = ChallengeAnnotationPrefix + "prerequisites"
+
+	// ChallengeTagsAnnotation is a comma separated list of tags shown to players, like the difficulty of the
+	// challenge. If not set, the tags are left unchanged. An empty value removes all tags.
+	ChallengeTagsAnnotation = ChallengeAnnotationPrefix + "tags"
+
+	// ChallengeTopicsAnnotation is a comma separated list of topics, which describe the learning objectives of the
+	// challenge. Topics are only visible to admins. If not set, the topics are left unchanged. An empty value removes
+	// all topics.
+	ChallengeTopicsAnnotation = ChallengeAnnotationPrefix + "topics"
+
+	// ChallengeFilesAnnotation provides files for players to download as a JSON list of ChallengeFile. Files are
+	// uploaded again when their content changes. Files in CTFd which are not part of the list are removed. If not set,
+	// the files of the challenge are left unchanged unless they were uploaded by the operator before.
+	ChallengeFilesAnnotation = ChallengeAnnotationPrefix + "files"
+
+	// ChallengeHintsAnnotation provides additional settings for the hints of the ChallengeDescription as a JSON list
+	// of ChallengeHintSettings. The entries are matched to the hints by their description, so that inserting or
+	// reordering hints keeps the settings with their hints.
+	ChallengeHintsAnnotation = ChallengeAnnotationPrefix + "hints"
+)
+
+// These are the challenge types supported by CTFd.
+const (
+	ChallengeTypeStandard = "standard"
+	ChallengeTypeDynamic  = "dynamic"
+)
+
+// These are the challenge states supported by CTFd.
+const (
+	ChallengeStateVisible = "visible"
+	ChallengeStateHidden  = "hidden"
+)
+
+// These are the decay functions of dynamic challenges supported by CTFd.
+const (
+	DecayFunctionLogarithmic = "logarithmic"
+	DecayFunctionLinear      = "linear"
+)
+
+// These are the flag types supported by CTFd.
+const (
+	FlagTypeStatic = "static"
+	FlagTypeRegex  = "regex"
+)
+
+// ChallengeFlag describes an additional flag which is accepted for a challenge.
+type ChallengeFlag struct {
+	// Content is the flag itself for static flags or the regular expression the flag needs to match for regex flags.
+	Content string `json:"content"`
+
+	// Type is either static or regex. If empty, static is used.
+	Type string `json:"type,omitempty"`
+
+	// CaseInsensitive ignores the case of the submitted flag when set to true.
+	CaseInsensitive bool `json:"caseInsensitive,omitempty"`
+}
+
+// ChallengeDynamicScoring describes a challenge whose value decreases with the number of solves. The value of the
+// ChallengeDescription is used as the initial value.
+type ChallengeDynamicScoring struct {
+	// Decay is the number of solves after which the minimum is reached for the logarithmic function. For the linear
+	// function, it is the number of points deducted per solve.
+	Decay int `json:"decay"`
+
+	// Minimum is the lowest value the challenge can reach.
+	Minimum int `json:"minimum"`
+
+	// Function is either logarithmic or linear. If empty, logarithmic is used.
+	Function string `json:"function,omitempty"`
+}
+
+// ChallengeFile describes a file which players can download for a challenge. Exactly one of ConfigMap, Secret and Minio
+// needs to be set.
+type ChallengeFile struct {
+	// Name is the name of the file as shown to players. If empty, the key or the last segment of the object is used.
+	Name string `json:"name,omitempty"`
+
+	// ConfigMap references a key of a ConfigMap in the namespace of the ChallengeDescription.
+	ConfigMap *ChallengeFileKeySelector `json:"configMap,omitempty"`
+
+	// Secret references a key of a Secret in the namespace of the ChallengeDescription.
+	Secret *ChallengeFileKeySelector `json:"secret,omitempty"`
+
+	// Minio references an object in the Minio bucket of the CTFd instance. Use this for files which are too large for
+	// ConfigMaps or Secrets.
+	Minio *ChallengeFileObjectSelector `json:"minio,omitempty"`
+}
+
+// ChallengeFileKeySelector references a key of a ConfigMap or Secret.
+type ChallengeFileKeySelector struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+// ChallengeFileObjectSelector references an object in a Minio bucket.
+type ChallengeFileObjectSelector struct {
+	Object string `json:"object"`
+}
+
+// ChallengeHintSettings describes the settings of a hint which the ChallengeDescription does not provide.
+type ChallengeHintSettings struct {
+	// Description is the description of the hint the settings apply to. It identifies the hint in the
+	// ChallengeDescription.
+	Description string `json:"description"`
+
+	// Title is shown to players before the hint is unlocked. If empty, hints without cost are titled as free hints.
+	Title string `json:"title,omitempty"`
+
+	// RequiresPrevious only allows players to unlock the hint after the previous hint was unlocked.
+	RequiresPrevious bool `json:"requiresPrevious,omitempty"`
+}
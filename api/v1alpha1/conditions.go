@@ -0,0 +1,34 @@
+package v1alpha1
+
+// These are the condition types which are reported in the status of the resources.
+const (
+	// ConditionTypeReady is true when the resource is up and running.
+	ConditionTypeReady = "Ready"
+
+	// ConditionTypeDependenciesReady is true when Redis, MariaDB and Minio of a CTFd instance are up and running.
+	ConditionTypeDependenciesReady = "DependenciesReady"
+
+	// ConditionTypeBucketReady is true when the Minio bucket for a CTFd instance exists.
+	ConditionTypeBucketReady = "BucketReady"
+
+	// ConditionTypeSetupComplete is true when the initial setup of a CTFd instance was done.
+	ConditionTypeSetupComplete = "SetupComplete"
+
+	// ConditionTypeAccessTokenValid is true when the operator has a working access token for a CTFd instance.
+	ConditionTypeAccessTokenValid = "AccessTokenValid"
+
+	// ConditionTypeConfigSynced is true when the configuration of a CTFd instance matches the spec.
+	ConditionTypeConfigSynced = "ConfigSynced"
+
+	// ConditionTypeChallengesSynced is true when all ChallengeDescriptions were reconciled into a CTFd instance.
+	ConditionTypeChallengesSynced = "ChallengesSynced"
+
+	// ConditionTypeRosterSynced is true when all Teams and Participants were reconciled into a CTFd instance.
+	ConditionTypeRosterSynced = "RosterSynced"
+
+	// ConditionTypeAwardsSynced is true when all Awards were reconciled into a CTFd instance.
+	ConditionTypeAwardsSynced = "AwardsSynced"
+
+	// ConditionTypeSynced is true when a Team, Participant or Award was reconciled into its CTFd instance.
+	ConditionTypeSynced = "Synced"
+)
@@ -96,10 +96,138 @@ type CTFdSpec struct {
 	Minio MinioSpec `json:"minio"`
 
 	// ChallengeNamespace provides the namespace to look for ChallengeDescription resources. Those are then reconciled
-	// into the instance. If nil is given, no ChallengeDescriptions are reconciled. If an empty string is given, the
-	// same namespace is used.
+	// into the instance. If nil is given and neither ChallengeNamespaces nor ChallengeNamespaceSelector are set, no
+	// ChallengeDescriptions are reconciled. If an empty string is given, the same namespace is used.
 	// +kubebuilder:validation:Optional
 	ChallengeNamespace *string `json:"challengeNamespace"`
+
+	// ChallengeNamespaces provides additional namespaces to look for ChallengeDescription resources.
+	// +kubebuilder:validation:Optional
+	ChallengeNamespaces []string `json:"challengeNamespaces,omitempty"`
+
+	// ChallengeNamespaceSelector selects additional namespaces by label to look for ChallengeDescription resources.
+	// Challenges from namespaces which stop matching are removed from the instance.
+	// +kubebuilder:validation:Optional
+	ChallengeNamespaceSelector *metav1.LabelSelector `json:"challengeNamespaceSelector,omitempty"`
+
+	// ChallengeSelector restricts the ChallengeDescriptions which are reconciled into the instance to those matching
+	// the label selector. This allows for splitting the ChallengeDescriptions of one namespace between several
+	// instances. If nil is given, all ChallengeDescriptions are reconciled. ChallengeDescriptions which stop matching
+	// are removed from the instance.
+	// +kubebuilder:validation:Optional
+	ChallengeSelector *metav1.LabelSelector `json:"challengeSelector,omitempty"`
+
+	// ChallengeSync configures how the challenges in CTFd are kept in sync with the ChallengeDescriptions.
+	// +kubebuilder:validation:Optional
+	ChallengeSync ChallengeSyncSpec `json:"challengeSync"`
+
+	// FinalExport provides the storage a full export of CTFd is uploaded to when the CTFd resource is deleted. If nil
+	// is given, no export is done.
+	// +kubebuilder:validation:Optional
+	FinalExport *FinalExportSpec `json:"finalExport"`
+}
+
+// These are the policies for handling drift between ChallengeDescriptions and CTFd.
+const (
+	// ChallengeSyncPolicyEnforce overwrites changes done in CTFd with the ChallengeDescription.
+	ChallengeSyncPolicyEnforce = "enforce"
+
+	// ChallengeSyncPolicyReportOnly keeps changes done in CTFd and reports them as drift.
+	ChallengeSyncPolicyReportOnly = "report-only"
+
+	// ChallengeSyncPolicyDeferToCTFd keeps changes done in CTFd until the ChallengeDescription itself changes.
+	ChallengeSyncPolicyDeferToCTFd = "defer-to-ctfd"
+
+	// ChallengeSyncPolicyAdopt is the original name of ChallengeSyncPolicyDeferToCTFd. It is still accepted, so that
+	// existing manifests keep working, but it is easily confused with UnmanagedChallengePolicyAdopt.
+	//
+	// Deprecated: Use ChallengeSyncPolicyDeferToCTFd instead.
+	ChallengeSyncPolicyAdopt = "adopt"
+)
+
+// These are the policies for handling challenges in CTFd which were not created by the operator.
+const (
+	// UnmanagedChallengePolicyDelete deletes all challenges which do not belong to a ChallengeDescription.
+	UnmanagedChallengePolicyDelete = "delete"
+
+	// UnmanagedChallengePolicyKeep leaves challenges which were not created by the operator alone.
+	UnmanagedChallengePolicyKeep = "keep"
+
+	// UnmanagedChallengePolicyAdopt takes over challenges which were not created by the operator when they match a
+	// ChallengeDescription by title and category. Other challenges are left alone.
+	UnmanagedChallengePolicyAdopt = "adopt"
+)
+
+// ChallengeSyncSpec describes how drift between the ChallengeDescriptions and the challenges in CTFd is handled.
+// Changes to a ChallengeDescription are always written to CTFd. The policy only applies to changes done in CTFd
+// directly, for example through the admin panel.
+type ChallengeSyncSpec struct {
+	// Interval is the time between two checks for drift. If nil is given, drift is only detected when a reconcile is
+	// triggered by some change in Kubernetes.
+	// +kubebuilder:validation:Optional
+	Interval *metav1.Duration `json:"interval"`
+
+	// Policy decides what happens when drift is detected. The value adopt is accepted as an alias of defer-to-ctfd.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=enforce;report-only;defer-to-ctfd;adopt
+	// +kubebuilder:default=enforce
+	Policy string `json:"policy"`
+
+	// UnmanagedPolicy decides what happens to challenges in CTFd which were not created by the operator, for example
+	// challenges created manually through the admin panel before the operator was pointed at the instance. Challenges
+	// created by the operator are tracked in the status and are always deleted when their ChallengeDescription is gone.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=delete;keep;adopt
+	// +kubebuilder:default=delete
+	UnmanagedPolicy string `json:"unmanagedPolicy"`
+
+	// Categories restricts the unmanaged policy to challenges in the given categories. Challenges in CTFd in other
+	// categories are neither deleted nor adopted. If empty, the unmanaged policy applies to all categories.
+	// +kubebuilder:validation:Optional
+	Categories []string `json:"categories,omitempty"`
+}
+
+func (s *ChallengeSyncSpec) GetPolicy() string {
+	switch s.Policy {
+	case "":
+		return ChallengeSyncPolicyEnforce
+	case ChallengeSyncPolicyAdopt:
+		return ChallengeSyncPolicyDeferToCTFd
+	default:
+		return s.Policy
+	}
+}
+
+func (s *ChallengeSyncSpec) GetUnmanagedPolicy() string {
+	if s.UnmanagedPolicy == "" {
+		return UnmanagedChallengePolicyDelete
+	}
+	return s.UnmanagedPolicy
+}
+
+// IsManagedCategory returns true if the unmanaged policy applies to challenges in the given category.
+func (s *ChallengeSyncSpec) IsManagedCategory(category string) bool {
+	return len(s.Categories) == 0 || slices.Contains(s.Categories, category)
+}
+
+// FinalExportSpec describes an S3 compatible storage for the final export of CTFd.
+type FinalExportSpec struct {
+	// Endpoint is the host and port of the S3 compatible storage.
+	// +kubebuilder:validation:Required
+	Endpoint string `json:"endpoint"`
+
+	// Secure enables TLS for the connection to the storage.
+	// +kubebuilder:validation:Optional
+	Secure bool `json:"secure"`
+
+	// Bucket is the name of the bucket the export is uploaded to. The bucket needs to exist.
+	// +kubebuilder:validation:Required
+	Bucket string `json:"bucket"`
+
+	// SecretName is the name of the secret in the same namespace which provides the credentials for the storage in
+	// the keys AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY.
+	// +kubebuilder:validation:Required
+	SecretName string `json:"secretName"`
 }
 
 // CTFdStatus defines the observed state of CTFd.
@@ -108,10 +236,33 @@ type CTFdStatus struct {
 	// +kubebuilder:validation:Optional
 	Ready bool `json:"ready"`
 
+	// ObservedGeneration is the generation of the resource which was last reconciled.
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions provide details about the current state of the resource.
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
 	// ChallengeDescriptions provides information which associates ChallengeDescription resources with database ids
 	// of some CTFd instance.
 	// +kubebuilder:validation:Optional
 	ChallengeDescriptions []ChallengeDescriptionStatus `json:"challengeDescriptions"`
+
+	// Teams provides information which associates Team resources with database ids of some CTFd instance.
+	// +kubebuilder:validation:Optional
+	Teams []CTFdTeamStatus `json:"teams,omitempty"`
+
+	// Participants provides information which associates Participant resources with database ids of some CTFd
+	// instance.
+	// +kubebuilder:validation:Optional
+	Participants []CTFdParticipantStatus `json:"participants,omitempty"`
+
+	// Awards provides information which associates Award resources with database ids of some CTFd instance.
+	// +kubebuilder:validation:Optional
+	Awards []CTFdAwardStatus `json:"awards,omitempty"`
 }
 
 func (s *CTFdStatus) GetChallengeDescriptionIndex(challengeDescription v1alpha1.ChallengeDescription) int {
@@ -127,15 +278,79 @@ type ChallengeDescriptionStatus struct {
 	Name      string `json:"name"`
 	Namespace string `json:"namespace"`
 
+	// ObservedGeneration is the generation of the ChallengeDescription which was last written to CTFd.
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ObservedAnnotations is a hash of the annotations of the ChallengeDescription which were last written to CTFd.
+	// Changes to annotations do not increase the generation, so we need to track them separately.
+	// +kubebuilder:validation:Optional
+	ObservedAnnotations string `json:"observedAnnotations,omitempty"`
+
+	// Drift lists the parts of the challenge in CTFd which differ from the ChallengeDescription. This is only
+	// populated when the drift is not corrected because of the challenge sync policy.
+	// +kubebuilder:validation:Optional
+	Drift []string `json:"drift,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	Hints []HintStatus `json:"hints"`
+
+	// +kubebuilder:validation:Optional
+	Files []FileStatus `json:"files,omitempty"`
 }
 
 // HintStatus provides bookkeeping information about which CTFd hint id a specific hint from the ChallengeDescription
 // was stored as.
 type HintStatus struct {
-	Id    int `json:"id"`    // Id is the database id in CTFd
-	Index int `json:"index"` // Index into the slice of hint in the ChallengeDescription
+	Id int `json:"id"` // Id is the database id in CTFd
+
+	// Key identifies the hint in the ChallengeDescription independent of its position. It is derived from the content
+	// of the hint.
+	// +kubebuilder:validation:Optional
+	Key string `json:"key,omitempty"`
+
+	// Index is the position of the hint in the ChallengeDescription. It identified hints before Key was introduced and
+	// is only read for migrating existing bookkeeping.
+	// +kubebuilder:validation:Optional
+	Index int `json:"index,omitempty"`
+}
+
+// FileStatus provides bookkeeping information about which CTFd file id a specific file from the ChallengeDescription
+// was uploaded as.
+type FileStatus struct {
+	Id int `json:"id"` // Id is the database id in CTFd
+
+	// Name is the name of the file as shown to players. It identifies the file in the ChallengeDescription.
+	Name string `json:"name"`
+
+	// Hash is the SHA-256 of the uploaded content. A file is only uploaded again when its content changes.
+	Hash string `json:"hash"`
+
+	// ETag is the ETag of the Minio object the file was uploaded from. The object is only downloaded again when its
+	// ETag changes.
+	// +kubebuilder:validation:Optional
+	ETag string `json:"etag,omitempty"`
+}
+
+// CTFdTeamStatus provides bookkeeping information about which CTFd team id a specific Team with the given name was
+// stored as. Teams are always located in the namespace of the CTFd instance.
+type CTFdTeamStatus struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// CTFdParticipantStatus provides bookkeeping information about which CTFd user id a specific Participant with the
+// given name was stored as. Participants are always located in the namespace of the CTFd instance.
+type CTFdParticipantStatus struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// CTFdAwardStatus provides bookkeeping information about which CTFd award id a specific Award with the given name was
+// stored as. Awards are always located in the namespace of the CTFd instance.
+type CTFdAwardStatus struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
 }
 
 // +kubebuilder:object:root=true
@@ -152,6 +367,10 @@ type CTFd struct {
 	Status CTFdStatus `json:"status,omitempty"`
 }
 
+func (r *CTFd) GetConditions() *[]metav1.Condition {
+	return &r.Status.Conditions
+}
+
 func (r *CTFd) GetDesiredLabels() map[string]string {
 	return map[string]string{
 		"app.kubernetes.io/name":     "CTFd",
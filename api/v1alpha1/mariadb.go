@@ -20,6 +20,16 @@ type MariaDBSpec struct {
 type MariaDBStatus struct {
 	// Ready is true when MariaDB is up and running.
 	Ready bool `json:"ready"`
+
+	// ObservedGeneration is the generation of the resource which was last reconciled.
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions provide details about the current state of the resource.
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -36,6 +46,10 @@ type MariaDB struct {
 	Status MariaDBStatus `json:"status,omitempty"`
 }
 
+func (r *MariaDB) GetConditions() *[]metav1.Condition {
+	return &r.Status.Conditions
+}
+
 func (r *MariaDB) GetDesiredLabels() map[string]string {
 	return map[string]string{
 		"app.kubernetes.io/name":     "MariaDB",
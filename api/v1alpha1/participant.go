@@ -0,0 +1,89 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ParticipantSpec defines the desired state of Participant.
+type ParticipantSpec struct {
+	// CTFdName is the name of the CTFd instance the participant is created in. The CTFd instance needs to be located
+	// in the same namespace as the participant.
+	// +kubebuilder:validation:Required
+	CTFdName string `json:"ctfdName"`
+
+	// Name is the name of the participant as shown in CTFd. It needs to be unique within the CTFd instance.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Email is the email address of the participant. It needs to be unique within the CTFd instance.
+	// +kubebuilder:validation:Required
+	Email string `json:"email"`
+
+	// TeamName is the name of the Team resource the participant is a member of. The team needs to be located in the
+	// same namespace as the participant. If empty, the participant is not a member of any team.
+	// +kubebuilder:validation:Optional
+	TeamName string `json:"teamName,omitempty"`
+
+	// Hidden hides the participant from the scoreboard.
+	// +kubebuilder:validation:Optional
+	Hidden bool `json:"hidden"`
+
+	// Banned prevents the participant from logging in.
+	// +kubebuilder:validation:Optional
+	Banned bool `json:"banned"`
+
+	// BracketId is the id of the bracket in CTFd the participant is competing in. Brackets are only supported by CTFd
+	// 3.7 and later. If nil is given, the bracket is left unchanged.
+	// +kubebuilder:validation:Optional
+	BracketId *int `json:"bracketId,omitempty"`
+}
+
+// ParticipantStatus defines the observed state of Participant.
+type ParticipantStatus struct {
+	// ObservedGeneration is the generation of the resource which was last reconciled.
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Id is the database id of the user in CTFd. It is zero as long as the participant was not created in CTFd.
+	// +kubebuilder:validation:Optional
+	Id int `json:"id,omitempty"`
+
+	// Conditions provide details about the current state of the resource.
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="CTFd",type="string",JSONPath=".spec.ctfdName"
+// +kubebuilder:printcolumn:name="Name",type="string",JSONPath=".spec.name"
+// +kubebuilder:printcolumn:name="Team",type="string",JSONPath=".spec.teamName"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Participant is the Schema for the participants API.
+type Participant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ParticipantSpec   `json:"spec,omitempty"`
+	Status ParticipantStatus `json:"status,omitempty"`
+}
+
+func (r *Participant) GetConditions() *[]metav1.Condition {
+	return &r.Status.Conditions
+}
+
+// +kubebuilder:object:root=true
+
+// ParticipantList contains a list of Participant.
+type ParticipantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Participant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Participant{}, &ParticipantList{})
+}
@@ -0,0 +1,83 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TeamSpec defines the desired state of Team.
+type TeamSpec struct {
+	// CTFdName is the name of the CTFd instance the team is created in. The CTFd instance needs to be located in the
+	// same namespace as the team.
+	// +kubebuilder:validation:Required
+	CTFdName string `json:"ctfdName"`
+
+	// Name is the name of the team as shown in CTFd. It needs to be unique within the CTFd instance.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Email is the email address of the team.
+	// +kubebuilder:validation:Optional
+	Email string `json:"email,omitempty"`
+
+	// Hidden hides the team from the scoreboard.
+	// +kubebuilder:validation:Optional
+	Hidden bool `json:"hidden"`
+
+	// Banned prevents the team from participating.
+	// +kubebuilder:validation:Optional
+	Banned bool `json:"banned"`
+
+	// BracketId is the id of the bracket in CTFd the team is competing in. Brackets are only supported by CTFd 3.7
+	// and later. If nil is given, the bracket is left unchanged.
+	// +kubebuilder:validation:Optional
+	BracketId *int `json:"bracketId,omitempty"`
+}
+
+// TeamStatus defines the observed state of Team.
+type TeamStatus struct {
+	// ObservedGeneration is the generation of the resource which was last reconciled.
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Id is the database id of the team in CTFd. It is zero as long as the team was not created in CTFd.
+	// +kubebuilder:validation:Optional
+	Id int `json:"id,omitempty"`
+
+	// Conditions provide details about the current state of the resource.
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="CTFd",type="string",JSONPath=".spec.ctfdName"
+// +kubebuilder:printcolumn:name="Name",type="string",JSONPath=".spec.name"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Team is the Schema for the teams API.
+type Team struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TeamSpec   `json:"spec,omitempty"`
+	Status TeamStatus `json:"status,omitempty"`
+}
+
+func (r *Team) GetConditions() *[]metav1.Condition {
+	return &r.Status.Conditions
+}
+
+// +kubebuilder:object:root=true
+
+// TeamList contains a list of Team.
+type TeamList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Team `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Team{}, &TeamList{})
+}
@@ -1,11 +1,16 @@
 package ctfd
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -20,9 +25,11 @@ type AccessTokenReconciler struct {
 	ctfdEndpoint CTFdEndpointStrategy
 }
 
-func NewAccessTokenReconciler(client client.Client, options ...SubReconcilerOption) *AccessTokenReconciler {
+var _ utils.SubFinalizer[*v1alpha1.CTFd] = (*AccessTokenReconciler)(nil)
+
+func NewAccessTokenReconciler(client client.Client, recorder record.EventRecorder, options ...SubReconcilerOption) *AccessTokenReconciler {
 	result := &AccessTokenReconciler{
-		DefaultSubReconciler: utils.NewDefaultSubReconciler(client),
+		DefaultSubReconciler: utils.NewDefaultSubReconciler(client, recorder),
 	}
 	for _, option := range options {
 		option(result)
@@ -46,20 +53,73 @@ func (r *AccessTokenReconciler) Reconcile(ctx context.Context, ctfd *v1alpha1.CT
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+
+	endpoint, err := r.ctfdEndpoint.GetEndpoint(ctx, ctfd)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if len(adminDetails.AccessToken) != 0 {
-		// We already have an access token for the admin. No need to create another one.
+		// We already have an access token for the admin. No need to create another one. We only make sure that the
+		// token is still accepted by CTFd.
 		ctrl.LoggerFrom(ctx).V(1).Info("Access token already available, skipping AccessTokenReconciler.")
-		return ctrl.Result{}, nil
+		if err := r.validateAccessToken(ctx, endpoint, adminDetails.AccessToken); err != nil {
+			r.GetRecorder().Eventf(ctfd, corev1.EventTypeWarning, "AccessTokenInvalid", "The access token in the admin secret was rejected: %s", err)
+			return ctrl.Result{}, errors.Join(err, r.SetCondition(
+				ctx,
+				ctfd,
+				v1alpha1.ConditionTypeAccessTokenValid,
+				metav1.ConditionFalse,
+				"AccessTokenInvalid",
+				"The access token in the admin secret was rejected, remove it to create a new one.",
+			))
+		}
+		return ctrl.Result{}, r.SetCondition(
+			ctx,
+			ctfd,
+			v1alpha1.ConditionTypeAccessTokenValid,
+			metav1.ConditionTrue,
+			"AccessTokenAccepted",
+			"The access token in the admin secret is accepted by CTFd.",
+		)
 	}
 
-	endpoint, err := r.ctfdEndpoint.GetEndpoint(ctx, ctfd)
+	if err := r.createAccessToken(ctx, ctfd, endpoint, adminDetails); err != nil {
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeWarning, "AccessTokenCreationFailed", "Failed to create access token: %s", err)
+		return ctrl.Result{}, errors.Join(err, r.SetCondition(
+			ctx,
+			ctfd,
+			v1alpha1.ConditionTypeAccessTokenValid,
+			metav1.ConditionFalse,
+			"AccessTokenCreationFailed",
+			"Failed to create an access token, check the AccessTokenCreationFailed events for details.",
+		))
+	}
+	return ctrl.Result{}, r.SetCondition(
+		ctx,
+		ctfd,
+		v1alpha1.ConditionTypeAccessTokenValid,
+		metav1.ConditionTrue,
+		"AccessTokenCreated",
+		"A new access token was created and stored in the admin secret.",
+	)
+}
+
+func (r *AccessTokenReconciler) validateAccessToken(ctx context.Context, endpoint string, accessToken string) error {
+	ctfdClient, err := ctfdapi.NewClient(endpoint, accessToken)
 	if err != nil {
-		return ctrl.Result{}, err
+		return err
+	}
+	if _, err := ctfdClient.ListTokens(ctx); err != nil {
+		return fmt.Errorf("validating access token: %w", err)
 	}
+	return nil
+}
 
+func (r *AccessTokenReconciler) createAccessToken(ctx context.Context, ctfd *v1alpha1.CTFd, endpoint string, adminDetails AdminDetails) error {
 	ctfdClient, err := ctfdapi.NewClient(endpoint, "")
 	if err != nil {
-		return ctrl.Result{}, err
+		return err
 	}
 
 	ctrl.LoggerFrom(ctx).Info("Creating access token")
@@ -67,7 +127,7 @@ func (r *AccessTokenReconciler) Reconcile(ctx context.Context, ctfd *v1alpha1.CT
 		Name:     adminDetails.Name,
 		Password: adminDetails.Password,
 	}); err != nil {
-		return ctrl.Result{}, fmt.Errorf("logging into CTFd: %w", err)
+		return fmt.Errorf("logging into CTFd: %w", err)
 	}
 
 	// NOTE: We are creating an access token with 6 months expiration. This should be long enough for any CTF event to
@@ -75,18 +135,18 @@ func (r *AccessTokenReconciler) Reconcile(ctx context.Context, ctfd *v1alpha1.CT
 	// enough for you, you need to delete the token from the admin secret before the expiration is reached, which will
 	// make this reconiler create a new token with 6 months expiration.
 	createTokenResponse, err := ctfdClient.CreateToken(ctx, ctfdapi.CreateTokenRequest{
-		Description: ctfd.Name + " (ctf-ui-operator)",
+		Description: accessTokenDescription(ctfd),
 		Expiration:  ctfdapi.NewDateOnly(time.Now().AddDate(0, 6, 0)),
 	})
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("creating access token: %w", err)
+		return fmt.Errorf("creating access token: %w", err)
 	}
 
 	if err := r.storeAccessToken(ctx, ctfd, createTokenResponse.Data.Value); err != nil {
-		return ctrl.Result{}, err
+		return err
 	}
-
-	return ctrl.Result{}, nil
+	r.GetRecorder().Event(ctfd, corev1.EventTypeNormal, "AccessTokenCreated", "Created access token and stored it in the admin secret")
+	return nil
 }
 
 func (r *AccessTokenReconciler) storeAccessToken(ctx context.Context, ctfd *v1alpha1.CTFd, accessToken string) error {
@@ -106,6 +166,83 @@ func (r *AccessTokenReconciler) storeAccessToken(ctx context.Context, ctfd *v1al
 	return nil
 }
 
+// Finalize revokes the access tokens of the operator, so that they cannot be used anymore after the CTFd resource
+// is gone.
+func (r *AccessTokenReconciler) Finalize(ctx context.Context, ctfd *v1alpha1.CTFd) (ctrl.Result, error) {
+	if !ctfd.Status.Ready {
+		// Without a running CTFd instance there is nothing we can revoke.
+		ctrl.LoggerFrom(ctx).V(1).Info("CTFd is not ready, skipping AccessTokenReconciler finalizer.")
+		return ctrl.Result{}, nil
+	}
+
+	adminDetails, err := GetAdminDetails(ctx, r.GetClient(), ctfd)
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if len(adminDetails.AccessToken) == 0 {
+		ctrl.LoggerFrom(ctx).V(1).Info("No access token available, skipping AccessTokenReconciler finalizer.")
+		return ctrl.Result{}, nil
+	}
+
+	endpoint, err := r.ctfdEndpoint.GetEndpoint(ctx, ctfd)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	ctfdClient, err := ctfdapi.NewClient(endpoint, adminDetails.AccessToken)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	tokens, err := ctfdClient.ListTokens(ctx)
+	if err != nil {
+		if ctfdapi.IsUnauthorized(err) {
+			// The access token was already revoked by a previous attempt. There is nothing left we could revoke with it.
+			ctrl.LoggerFrom(ctx).Info("Access token was already revoked, skipping AccessTokenReconciler finalizer.")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("listing access tokens: %w", err)
+	}
+	for _, token := range r.getTokensToRevoke(ctfd, tokens, adminDetails.AccessToken) {
+		ctrl.LoggerFrom(ctx).Info("Revoking access token", "id", token.Id)
+		if _, err := ctfdClient.DeleteToken(ctx, token.Id); err != nil {
+			if ctfdapi.IsUnauthorized(err) {
+				// The access token we are sending requests with is gone. The remaining tokens can not be revoked anymore.
+				ctrl.LoggerFrom(ctx).Info("Access token was revoked, skipping the remaining access tokens.")
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("revoking access token: %w", err)
+		}
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "AccessTokenRevoked", "Revoked access token with id %d", token.Id)
+	}
+	return ctrl.Result{}, nil
+}
+
+// getTokensToRevoke returns the access tokens of the operator in the order they need to be revoked. The access token
+// in use is revoked last, as CTFd rejects all requests after that. It is identified by its value, or as the most
+// recent access token when CTFd does not provide the values.
+func (r *AccessTokenReconciler) getTokensToRevoke(ctfd *v1alpha1.CTFd, tokens []ctfdapi.Token, accessToken string) []ctfdapi.Token {
+	result := slices.DeleteFunc(slices.Clone(tokens), func(token ctfdapi.Token) bool {
+		return token.Description != accessTokenDescription(ctfd)
+	})
+	slices.SortFunc(result, func(lhs ctfdapi.Token, rhs ctfdapi.Token) int {
+		return cmp.Compare(lhs.Id, rhs.Id)
+	})
+	if tokenIdx := slices.IndexFunc(result, func(token ctfdapi.Token) bool {
+		return token.Value == accessToken
+	}); tokenIdx != -1 {
+		token := result[tokenIdx]
+		result = append(slices.Delete(result, tokenIdx, tokenIdx+1), token)
+	}
+	return result
+}
+
 func (r *AccessTokenReconciler) SetCTFdEndpoint(endpoint CTFdEndpointStrategy) {
 	r.ctfdEndpoint = endpoint
 }
+
+// accessTokenDescription returns the description the access tokens of the operator are created with. The description
+// allows us to find our own access tokens again.
+func accessTokenDescription(ctfd *v1alpha1.CTFd) string {
+	return ctfd.Name + " (ctf-ui-operator)"
+}
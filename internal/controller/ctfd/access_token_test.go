@@ -1,8 +1,11 @@
 package ctfd_test
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -18,7 +21,7 @@ var _ = Describe("AccessTokenReconciler", func() {
 	var reconciler *utils.Reconciler[*v1alpha1.CTFd]
 
 	BeforeEach(func() {
-		reconciler = ctfd.NewReconciler(k8sClient, ctfd.WithAccessTokenReconciler(WithCTFdTestEndpoint(endpointUrl)))
+		reconciler = ctfd.NewReconciler(k8sClient, recorder, ctfd.WithAccessTokenReconciler(WithCTFdTestEndpoint(endpointUrl)))
 	})
 
 	AfterEach(func(ctx SpecContext) {
@@ -56,5 +59,88 @@ var _ = Describe("AccessTokenReconciler", func() {
 		ctfdClient, err := ctfdapi.NewClient(endpointUrl, adminDetails.AccessToken)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(ctfdClient.ListTokens(ctx)).Error().To(Succeed())
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		Expect(instance.Status.Conditions).To(ContainElement(And(
+			HaveField("Type", v1alpha1.ConditionTypeAccessTokenValid),
+			HaveField("Status", metav1.ConditionTrue),
+		)))
+		Expect(RecordedEvents()).To(ContainElement(HavePrefix("Normal AccessTokenCreated")))
+	})
+
+	It("should revoke the access token when the CTFd is deleted", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, nil)).To(Succeed())
+
+		Expect(reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))).To(BeZero())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		Expect(instance.Finalizers).To(ContainElement(utils.FinalizerName))
+		Expect(k8sClient.Delete(ctx, &instance)).To(Succeed())
+
+		By("run the reconciler")
+		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		By("verify all postconditions")
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).ToNot(Succeed())
+
+		ctfdClient, err := ctfdapi.NewClient(endpointUrl, accessToken)
+		Expect(err).ToNot(HaveOccurred())
+		tokens, err := ctfdClient.ListTokens(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tokens).ToNot(ContainElement(HaveField("Description", HavePrefix(instance.Name+" "))))
+		Expect(RecordedEvents()).To(ContainElement(HavePrefix("Normal AccessTokenRevoked")))
+	})
+
+	It("should finish the finalizer when the access token in use is revoked before other access tokens", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, nil)).To(Succeed())
+
+		Expect(reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))).To(BeZero())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		adminDetails, err := ctfd.GetAdminDetails(ctx, k8sClient, &instance)
+		Expect(err).ToNot(HaveOccurred())
+
+		// A more recent access token with the same description is revoked after the access token in use, when CTFd
+		// does not provide the values of the access tokens.
+		ctfdClient, err := ctfdapi.NewClient(endpointUrl, accessToken)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ctfdClient.CreateToken(ctx, ctfdapi.CreateTokenRequest{
+			Description: instance.Name + " (ctf-ui-operator)",
+			Expiration:  ctfdapi.NewDateOnly(time.Now().AddDate(0, 0, 1)),
+		})).Error().ToNot(HaveOccurred())
+		Expect(k8sClient.Delete(ctx, &instance)).To(Succeed())
+
+		By("run the reconciler")
+		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		By("verify all postconditions")
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).ToNot(Succeed())
+
+		operatorClient, err := ctfdapi.NewClient(endpointUrl, adminDetails.AccessToken)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = operatorClient.ListTokens(ctx)
+		Expect(ctfdapi.IsUnauthorized(err)).To(BeTrue())
 	})
 })
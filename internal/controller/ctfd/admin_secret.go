@@ -8,6 +8,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -23,9 +24,9 @@ type AdminSecretReconciler struct {
 	utils.DefaultSubReconciler
 }
 
-func NewAdminSecretReconciler(client client.Client) *AdminSecretReconciler {
+func NewAdminSecretReconciler(client client.Client, recorder record.EventRecorder) *AdminSecretReconciler {
 	return &AdminSecretReconciler{
-		DefaultSubReconciler: utils.NewDefaultSubReconciler(client),
+		DefaultSubReconciler: utils.NewDefaultSubReconciler(client, recorder),
 	}
 }
 
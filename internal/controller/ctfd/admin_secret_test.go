@@ -18,7 +18,7 @@ var _ = Describe("AdminSecretReconciler", func() {
 	var reconciler *utils.Reconciler[*v1alpha1.CTFd]
 
 	BeforeEach(func() {
-		reconciler = ctfd.NewReconciler(k8sClient, ctfd.WithAdminSecretReconciler())
+		reconciler = ctfd.NewReconciler(k8sClient, recorder, ctfd.WithAdminSecretReconciler())
 	})
 
 	AfterEach(func(ctx SpecContext) {
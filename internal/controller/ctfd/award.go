@@ -0,0 +1,395 @@
+package ctfd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/backbone81/ctf-ui-operator/api/v1alpha1"
+	"github.com/backbone81/ctf-ui-operator/internal/ctfdapi"
+	"github.com/backbone81/ctf-ui-operator/internal/utils"
+)
+
+// +kubebuilder:rbac:groups=ui.ctf.backbone81,resources=awards,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ui.ctf.backbone81,resources=awards/status,verbs=get;update;patch
+
+// AwardReconciler is responsible for reconciling Award resources into the instance. Awards reference participants and
+// teams through the bookkeeping of the RosterReconciler and therefore need to run after it.
+type AwardReconciler struct {
+	utils.DefaultSubReconciler
+	ctfdEndpoint CTFdEndpointStrategy
+}
+
+func NewAwardReconciler(client client.Client, recorder record.EventRecorder, options ...SubReconcilerOption) *AwardReconciler {
+	result := &AwardReconciler{
+		DefaultSubReconciler: utils.NewDefaultSubReconciler(client, recorder),
+	}
+	for _, option := range options {
+		option(result)
+	}
+
+	if result.ctfdEndpoint == nil {
+		panic("CTFd endpoint strategy required")
+	}
+	return result
+}
+
+func (r *AwardReconciler) SetupWithManager(ctrlBuilder *builder.Builder) *builder.Builder {
+	// Changes to the status are done by ourselves and do not need to trigger a reconcile.
+	return ctrlBuilder.Watches(
+		&v1alpha1.Award{},
+		handler.EnqueueRequestsFromMapFunc(r.MapAwardToCTFd),
+		builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+	)
+}
+
+// MapAwardToCTFd returns a reconcile request for the CTFd instance the given Award references.
+func (r *AwardReconciler) MapAwardToCTFd(ctx context.Context, obj client.Object) []reconcile.Request {
+	award, ok := obj.(*v1alpha1.Award)
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{
+		{
+			NamespacedName: client.ObjectKey{
+				Namespace: award.Namespace,
+				Name:      award.Spec.CTFdName,
+			},
+		},
+	}
+}
+
+func (r *AwardReconciler) Blocking() bool {
+	// A broken award must not prevent the other sub-reconcilers from running.
+	return false
+}
+
+func (r *AwardReconciler) Reconcile(ctx context.Context, ctfd *v1alpha1.CTFd) (ctrl.Result, error) {
+	k8sAwards, err := r.listK8sAwards(ctx, ctfd)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(k8sAwards) == 0 && len(ctfd.Status.Awards) == 0 {
+		ctrl.LoggerFrom(ctx).V(1).Info("No awards provided, skipping AwardReconciler.")
+		return ctrl.Result{}, r.RemoveCondition(ctx, ctfd, v1alpha1.ConditionTypeAwardsSynced)
+	}
+	if !ctfd.Status.Ready {
+		// The CTFd instance is not ready. We try again later when the instance is up and running. The next reconcile
+		// will be triggered when the status changes.
+		ctrl.LoggerFrom(ctx).V(1).Info("CTFd is not ready, skipping AwardReconciler.")
+		return ctrl.Result{}, nil
+	}
+
+	adminDetails, err := GetAdminDetails(ctx, r.GetClient(), ctfd)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	endpoint, err := r.ctfdEndpoint.GetEndpoint(ctx, ctfd)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	ctfdClient, err := ctfdapi.NewClient(endpoint, adminDetails.AccessToken)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.reconcileAwards(ctx, ctfdClient, ctfd, k8sAwards); err != nil {
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeWarning, "AwardSyncFailed", "Failed to sync awards: %s", err)
+		return ctrl.Result{}, errors.Join(err, r.SetCondition(
+			ctx,
+			ctfd,
+			v1alpha1.ConditionTypeAwardsSynced,
+			metav1.ConditionFalse,
+			"AwardSyncFailed",
+			"Failed to sync awards, check the AwardSyncFailed events for details.",
+		))
+	}
+	return ctrl.Result{}, r.SetCondition(
+		ctx,
+		ctfd,
+		v1alpha1.ConditionTypeAwardsSynced,
+		metav1.ConditionTrue,
+		"AwardsSynced",
+		fmt.Sprintf("%d awards are synced.", len(ctfd.Status.Awards)),
+	)
+}
+
+// listK8sAwards returns all Awards which reference the given CTFd instance and which are not being deleted.
+func (r *AwardReconciler) listK8sAwards(ctx context.Context, ctfd *v1alpha1.CTFd) ([]v1alpha1.Award, error) {
+	var awardList v1alpha1.AwardList
+	if err := r.GetClient().List(ctx, &awardList, client.InNamespace(ctfd.Namespace)); err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(awardList.Items, func(k8sAward v1alpha1.Award) bool {
+		return k8sAward.Spec.CTFdName != ctfd.Name || !k8sAward.DeletionTimestamp.IsZero()
+	}), nil
+}
+
+// reconcileAwards syncs all Awards into CTFd. Awards in CTFd which were not created by the operator are left alone.
+func (r *AwardReconciler) reconcileAwards(ctx context.Context, ctfdClient *ctfdapi.Client, ctfd *v1alpha1.CTFd, k8sAwards []v1alpha1.Award) error {
+	ctfdAwards, err := ctfdClient.ListAwards(ctx)
+	if err != nil {
+		return err
+	}
+
+	awardStatusBefore := slices.Clone(ctfd.Status.Awards)
+	r.cleanupAwardStatus(ctfd, k8sAwards, ctfdAwards)
+
+	// An award referencing a missing participant or team must not prevent the other awards from being synced. We
+	// report the error at the end.
+	var result error
+	for i := range k8sAwards {
+		k8sAward := &k8sAwards[i]
+		desiredAward, err := r.getDesiredAward(ctfd, *k8sAward)
+		if err != nil {
+			result = errors.Join(result, err)
+			if err := r.updateAwardStatus(
+				ctx,
+				ctfd,
+				k8sAward,
+				metav1.ConditionFalse,
+				"InvalidRecipient",
+				"The award needs to reference exactly one existing participant or team.",
+			); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := r.reconcileAward(ctx, ctfdClient, ctfd, ctfdAwards, *k8sAward, desiredAward); err != nil {
+			return err
+		}
+		if err := r.updateAwardStatus(
+			ctx,
+			ctfd,
+			k8sAward,
+			metav1.ConditionTrue,
+			"Synced",
+			"The award is synced into CTFd.",
+		); err != nil {
+			return err
+		}
+	}
+
+	if err := r.deleteObsoleteAwards(ctx, ctfdClient, ctfdAwards, awardStatusBefore, ctfd); err != nil {
+		return err
+	}
+
+	if !equality.Semantic.DeepEqual(awardStatusBefore, ctfd.Status.Awards) {
+		if err := r.GetClient().Status().Update(ctx, ctfd); err != nil {
+			return err
+		}
+	}
+	return result
+}
+
+// reconcileAward creates or replaces the given award in CTFd, depending on the bookkeeping and the current state of the
+// award in CTFd.
+func (r *AwardReconciler) reconcileAward(ctx context.Context, ctfdClient *ctfdapi.Client, ctfd *v1alpha1.CTFd, ctfdAwards []ctfdapi.Award, k8sAward v1alpha1.Award, desiredAward ctfdapi.Award) error {
+	awardStatusIndex := r.getAwardStatusIndex(ctfd.Status.Awards, k8sAward.Name)
+	if awardStatusIndex == -1 {
+		return r.createAward(ctx, ctfdClient, ctfd, k8sAward, desiredAward)
+	}
+
+	ctfdAward := ctfdAwards[r.getCTFdAwardIndex(ctfdAwards, ctfd.Status.Awards[awardStatusIndex].Id)]
+	if r.isSameAward(ctfdAward, desiredAward) {
+		return nil
+	}
+	return r.replaceAward(ctx, ctfdClient, ctfd, awardStatusIndex, ctfdAward, desiredAward)
+}
+
+// updateAwardStatus writes the id from the bookkeeping and the given Synced condition into the status of the Award. An
+// award which failed to sync keeps its previous status, which makes the outdated observed generation visible.
+func (r *AwardReconciler) updateAwardStatus(ctx context.Context, ctfd *v1alpha1.CTFd, k8sAward *v1alpha1.Award, status metav1.ConditionStatus, reason string, message string) error {
+	statusBefore := k8sAward.Status.DeepCopy()
+	k8sAward.Status.ObservedGeneration = k8sAward.Generation
+	k8sAward.Status.Id = 0
+	if awardStatusIndex := r.getAwardStatusIndex(ctfd.Status.Awards, k8sAward.Name); awardStatusIndex != -1 {
+		k8sAward.Status.Id = ctfd.Status.Awards[awardStatusIndex].Id
+	}
+	meta.SetStatusCondition(&k8sAward.Status.Conditions, metav1.Condition{
+		Type:               v1alpha1.ConditionTypeSynced,
+		Status:             status,
+		ObservedGeneration: k8sAward.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+	if equality.Semantic.DeepEqual(statusBefore, &k8sAward.Status) {
+		return nil
+	}
+	return r.GetClient().Status().Update(ctx, k8sAward)
+}
+
+func (r *AwardReconciler) cleanupAwardStatus(ctfd *v1alpha1.CTFd, k8sAwards []v1alpha1.Award, ctfdAwards []ctfdapi.Award) {
+	// Remove awards from the bookkeeping which can not be found in Kubernetes anymore.
+	ctfd.Status.Awards = slices.DeleteFunc(ctfd.Status.Awards, func(awardStatus v1alpha1.CTFdAwardStatus) bool {
+		return !slices.ContainsFunc(k8sAwards, func(k8sAward v1alpha1.Award) bool {
+			return k8sAward.Name == awardStatus.Name
+		})
+	})
+
+	// Remove awards from the bookkeeping which can not be found in CTFd anymore. This happens when the participant or
+	// team of the award was deleted, as CTFd deletes their awards as well.
+	ctfd.Status.Awards = slices.DeleteFunc(ctfd.Status.Awards, func(awardStatus v1alpha1.CTFdAwardStatus) bool {
+		return r.getCTFdAwardIndex(ctfdAwards, awardStatus.Id) == -1
+	})
+}
+
+func (r *AwardReconciler) getCTFdAwardIndex(ctfdAwards []ctfdapi.Award, id int) int {
+	return slices.IndexFunc(ctfdAwards, func(ctfdAward ctfdapi.Award) bool {
+		return ctfdAward.Id == id
+	})
+}
+
+func (r *AwardReconciler) getAwardStatusIndex(awardStatus []v1alpha1.CTFdAwardStatus, name string) int {
+	return slices.IndexFunc(awardStatus, func(awardStatus v1alpha1.CTFdAwardStatus) bool {
+		return awardStatus.Name == name
+	})
+}
+
+// getDesiredAward returns the award as it should exist in CTFd. The participant or team of the award is looked up in
+// the bookkeeping of the roster.
+func (r *AwardReconciler) getDesiredAward(ctfd *v1alpha1.CTFd, k8sAward v1alpha1.Award) (ctfdapi.Award, error) {
+	result := ctfdapi.Award{
+		Type:        ctfdapi.AwardTypeStandard,
+		Name:        k8sAward.Spec.Name,
+		Description: k8sAward.Spec.Description,
+		Value:       k8sAward.Spec.Value,
+		Category:    k8sAward.Spec.Category,
+		Icon:        k8sAward.Spec.Icon,
+	}
+	switch {
+	case len(k8sAward.Spec.ParticipantName) != 0 && len(k8sAward.Spec.TeamName) != 0:
+		return ctfdapi.Award{}, fmt.Errorf("award %q must not reference both a participant and a team", k8sAward.Name)
+	case len(k8sAward.Spec.ParticipantName) != 0:
+		participantStatusIndex := slices.IndexFunc(ctfd.Status.Participants, func(participantStatus v1alpha1.CTFdParticipantStatus) bool {
+			return participantStatus.Name == k8sAward.Spec.ParticipantName
+		})
+		if participantStatusIndex == -1 {
+			return ctfdapi.Award{}, fmt.Errorf("participant %q of award %q does not exist", k8sAward.Spec.ParticipantName, k8sAward.Name)
+		}
+		result.UserId = &ctfd.Status.Participants[participantStatusIndex].Id
+	case len(k8sAward.Spec.TeamName) != 0:
+		teamStatusIndex := slices.IndexFunc(ctfd.Status.Teams, func(teamStatus v1alpha1.CTFdTeamStatus) bool {
+			return teamStatus.Name == k8sAward.Spec.TeamName
+		})
+		if teamStatusIndex == -1 {
+			return ctfdapi.Award{}, fmt.Errorf("team %q of award %q does not exist", k8sAward.Spec.TeamName, k8sAward.Name)
+		}
+		result.TeamId = &ctfd.Status.Teams[teamStatusIndex].Id
+	default:
+		return ctfdapi.Award{}, fmt.Errorf("award %q must reference either a participant or a team", k8sAward.Name)
+	}
+	return result, nil
+}
+
+func (r *AwardReconciler) isSameAward(ctfdAward ctfdapi.Award, desiredAward ctfdapi.Award) bool {
+	// In team mode, CTFd fills in the team of the user. We therefore only compare the team when the award is given to
+	// a team.
+	return ctfdAward.Name == desiredAward.Name &&
+		ctfdAward.Description == desiredAward.Description &&
+		ctfdAward.Value == desiredAward.Value &&
+		ctfdAward.Category == desiredAward.Category &&
+		ctfdAward.Icon == desiredAward.Icon &&
+		(desiredAward.UserId == nil || ctfdAward.UserId != nil && *ctfdAward.UserId == *desiredAward.UserId) &&
+		(desiredAward.TeamId == nil || ctfdAward.TeamId != nil && *ctfdAward.TeamId == *desiredAward.TeamId)
+}
+
+func (r *AwardReconciler) createAward(ctx context.Context, ctfdClient *ctfdapi.Client, ctfd *v1alpha1.CTFd, k8sAward v1alpha1.Award, desiredAward ctfdapi.Award) error {
+	ctrl.LoggerFrom(ctx).Info(
+		"Creating award",
+		"name", desiredAward.Name,
+		"value", desiredAward.Value,
+	)
+	ctfdAward, err := ctfdClient.CreateAward(ctx, desiredAward)
+	if err != nil {
+		return err
+	}
+	r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "AwardCreated", "Created award %q worth %d points with id %d", ctfdAward.Name, ctfdAward.Value, ctfdAward.Id)
+	ctfd.Status.Awards = append(ctfd.Status.Awards, v1alpha1.CTFdAwardStatus{
+		Id:   ctfdAward.Id,
+		Name: k8sAward.Name,
+	})
+	return r.GetClient().Status().Update(ctx, ctfd)
+}
+
+// replaceAward creates the desired award and points the bookkeeping to it. Awards can not be updated in CTFd. The old
+// award is deleted as soon as the new one is persisted in the bookkeeping, as a later failure would otherwise leave it
+// behind as an unmanaged award and the points would be counted twice.
+func (r *AwardReconciler) replaceAward(ctx context.Context, ctfdClient *ctfdapi.Client, ctfd *v1alpha1.CTFd, awardStatusIndex int, ctfdAward ctfdapi.Award, desiredAward ctfdapi.Award) error {
+	ctrl.LoggerFrom(ctx).Info(
+		"Replacing award",
+		"id", ctfdAward.Id,
+		"name", desiredAward.Name,
+		"value", desiredAward.Value,
+	)
+	newCTFdAward, err := ctfdClient.CreateAward(ctx, desiredAward)
+	if err != nil {
+		return err
+	}
+	r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "AwardReplaced", "Replaced award %q with id %d by award %q worth %d points with id %d", ctfdAward.Name, ctfdAward.Id, newCTFdAward.Name, newCTFdAward.Value, newCTFdAward.Id)
+	ctfd.Status.Awards[awardStatusIndex].Id = newCTFdAward.Id
+	if err := r.GetClient().Status().Update(ctx, ctfd); err != nil {
+		return err
+	}
+
+	ctrl.LoggerFrom(ctx).Info(
+		"Deleting award",
+		"id", ctfdAward.Id,
+		"name", ctfdAward.Name,
+	)
+	if err := ctfdapi.IgnoreNotFound(ctfdClient.DeleteAward(ctx, ctfdAward.Id)); err != nil {
+		return err
+	}
+	r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "AwardDeleted", "Deleted award %q with id %d", ctfdAward.Name, ctfdAward.Id)
+	return nil
+}
+
+// deleteObsoleteAwards deletes all awards which are not part of the bookkeeping anymore. Awards which were never part
+// of the bookkeeping were not created by the operator and are left alone.
+func (r *AwardReconciler) deleteObsoleteAwards(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdAwards []ctfdapi.Award, awardStatusBefore []v1alpha1.CTFdAwardStatus, ctfd *v1alpha1.CTFd) error {
+	for _, awardStatus := range awardStatusBefore {
+		if slices.Contains(ctfd.Status.Awards, awardStatus) {
+			continue
+		}
+		ctfdAwardIndex := r.getCTFdAwardIndex(ctfdAwards, awardStatus.Id)
+		if ctfdAwardIndex == -1 {
+			// The award was already deleted in CTFd.
+			continue
+		}
+		ctfdAward := ctfdAwards[ctfdAwardIndex]
+		ctrl.LoggerFrom(ctx).Info(
+			"Deleting award",
+			"id", ctfdAward.Id,
+			"name", ctfdAward.Name,
+		)
+		if err := ctfdClient.DeleteAward(ctx, ctfdAward.Id); err != nil {
+			if ctfdapi.IsNotFound(err) {
+				// Replaced awards delete their old award on their own.
+				continue
+			}
+			return err
+		}
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "AwardDeleted", "Deleted award %q with id %d", ctfdAward.Name, ctfdAward.Id)
+	}
+	return nil
+}
+
+func (r *AwardReconciler) SetCTFdEndpoint(endpoint CTFdEndpointStrategy) {
+	r.ctfdEndpoint = endpoint
+}
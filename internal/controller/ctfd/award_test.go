@@ -0,0 +1,331 @@
+package ctfd_test
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/backbone81/ctf-ui-operator/api/v1alpha1"
+	"github.com/backbone81/ctf-ui-operator/internal/controller/ctfd"
+	"github.com/backbone81/ctf-ui-operator/internal/ctfdapi"
+	"github.com/backbone81/ctf-ui-operator/internal/testutils"
+	"github.com/backbone81/ctf-ui-operator/internal/utils"
+)
+
+var _ = Describe("AwardReconciler", func() {
+	var (
+		reconciler *utils.Reconciler[*v1alpha1.CTFd]
+		ctfdClient *ctfdapi.Client
+	)
+
+	BeforeEach(func() {
+		reconciler = ctfd.NewReconciler(
+			k8sClient,
+			recorder,
+			ctfd.WithRosterReconciler(WithCTFdTestEndpoint(endpointUrl)),
+			ctfd.WithAwardReconciler(WithCTFdTestEndpoint(endpointUrl)),
+		)
+		var err error
+		ctfdClient, err = ctfdapi.NewClient(endpointUrl, accessToken)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func(ctx SpecContext) {
+		Expect(k8sClient.DeleteAllOf(ctx, &v1alpha1.Award{}, client.InNamespace(corev1.NamespaceDefault))).To(Succeed())
+		Expect(k8sClient.DeleteAllOf(ctx, &v1alpha1.Team{}, client.InNamespace(corev1.NamespaceDefault))).To(Succeed())
+		DeleteAllInstances(ctx)
+		awards, err := ctfdClient.ListAwards(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		for _, award := range awards {
+			Expect(ctfdClient.DeleteAward(ctx, award.Id)).To(Succeed())
+		}
+		teams, err := ctfdClient.ListTeams(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		for _, team := range teams {
+			Expect(ctfdClient.DeleteTeam(ctx, team.Id)).To(Succeed())
+		}
+	})
+
+	It("should create awards", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		team := v1alpha1.Team{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.TeamSpec{
+				CTFdName: instance.Name,
+				Name:     "Red Team",
+			},
+		}
+		Expect(k8sClient.Create(ctx, &team)).To(Succeed())
+		award := v1alpha1.Award{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.AwardSpec{
+				CTFdName:    instance.Name,
+				TeamName:    team.Name,
+				Name:        "Write-up",
+				Description: "Excellent write-up for the web challenge.",
+				Value:       50,
+			},
+		}
+		Expect(k8sClient.Create(ctx, &award)).To(Succeed())
+
+		By("run the reconciler")
+		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		By("verify all postconditions")
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		Expect(instance.Status.Awards).To(ConsistOf(HaveField("Name", award.Name)))
+		Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, v1alpha1.ConditionTypeAwardsSynced)).To(BeTrue())
+
+		ctfdAward, err := ctfdClient.GetAward(ctx, instance.Status.Awards[0].Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ctfdAward.Name).To(Equal("Write-up"))
+		Expect(ctfdAward.Value).To(Equal(50))
+		Expect(ctfdAward.TeamId).To(HaveValue(Equal(instance.Status.Teams[0].Id)))
+		Expect(RecordedEvents()).To(ContainElement(HavePrefix("Normal AwardCreated")))
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&award), &award)).To(Succeed())
+		Expect(award.Status.Id).To(Equal(instance.Status.Awards[0].Id))
+		Expect(award.Status.ObservedGeneration).To(Equal(award.Generation))
+		Expect(meta.IsStatusConditionTrue(award.Status.Conditions, v1alpha1.ConditionTypeSynced)).To(BeTrue())
+	})
+
+	It("should replace and delete awards", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		team := v1alpha1.Team{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.TeamSpec{
+				CTFdName: instance.Name,
+				Name:     "Red Team",
+			},
+		}
+		Expect(k8sClient.Create(ctx, &team)).To(Succeed())
+		replacedAward := v1alpha1.Award{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.AwardSpec{
+				CTFdName: instance.Name,
+				TeamName: team.Name,
+				Name:     "Rule violation",
+				Value:    -100,
+			},
+		}
+		Expect(k8sClient.Create(ctx, &replacedAward)).To(Succeed())
+		deletedAward := v1alpha1.Award{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.AwardSpec{
+				CTFdName: instance.Name,
+				TeamName: team.Name,
+				Name:     "Write-up",
+				Value:    50,
+			},
+		}
+		Expect(k8sClient.Create(ctx, &deletedAward)).To(Succeed())
+		Expect(reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))).Error().ToNot(HaveOccurred())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		Expect(instance.Status.Awards).To(HaveLen(2))
+		oldAwardId := instance.Status.Awards[slices.IndexFunc(instance.Status.Awards, func(awardStatus v1alpha1.CTFdAwardStatus) bool {
+			return awardStatus.Name == replacedAward.Name
+		})].Id
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&replacedAward), &replacedAward)).To(Succeed())
+		replacedAward.Spec.Value = -50
+		Expect(k8sClient.Update(ctx, &replacedAward)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, &deletedAward)).To(Succeed())
+
+		By("run the reconciler")
+		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		By("verify all postconditions")
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		Expect(instance.Status.Awards).To(ConsistOf(HaveField("Name", replacedAward.Name)))
+		Expect(instance.Status.Awards[0].Id).ToNot(Equal(oldAwardId))
+		ctfdAward, err := ctfdClient.GetAward(ctx, instance.Status.Awards[0].Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ctfdAward.Value).To(Equal(-50))
+		ctfdAwards, err := ctfdClient.ListAwards(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ctfdAwards).To(HaveLen(1))
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&replacedAward), &replacedAward)).To(Succeed())
+		Expect(replacedAward.Status.Id).To(Equal(instance.Status.Awards[0].Id))
+		Expect(replacedAward.Status.ObservedGeneration).To(Equal(replacedAward.Generation))
+
+		events := RecordedEvents()
+		Expect(events).To(ContainElement(HavePrefix("Normal AwardReplaced")))
+		Expect(events).To(ContainElement(HavePrefix("Normal AwardDeleted")))
+	})
+
+	It("should delete the old award when a step after replacing the award fails", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		// The roster is not reconciled in this test, which allows the bookkeeping to reference a participant which does
+		// not exist in CTFd.
+		reconciler = ctfd.NewReconciler(
+			k8sClient,
+			recorder,
+			ctfd.WithAwardReconciler(WithCTFdTestEndpoint(endpointUrl)),
+		)
+		ctfdTeam, err := ctfdClient.CreateTeam(ctx, ctfdapi.Team{
+			Name:     "Red Team",
+			Email:    "red-team@ctfd.internal",
+			Password: "red-team",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		instance.Status.Ready = true
+		instance.Status.Teams = []v1alpha1.CTFdTeamStatus{
+			{
+				Id:   ctfdTeam.Id,
+				Name: "red-team",
+			},
+		}
+		instance.Status.Participants = []v1alpha1.CTFdParticipantStatus{
+			{
+				Id:   999999,
+				Name: "deleted-participant",
+			},
+		}
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		// Awards are processed in the order of their names, which makes the failing award come after the replaced one.
+		replacedAward := v1alpha1.Award{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "a-replaced",
+				Namespace: corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.AwardSpec{
+				CTFdName: instance.Name,
+				TeamName: "red-team",
+				Name:     "Write-up",
+				Value:    50,
+			},
+		}
+		Expect(k8sClient.Create(ctx, &replacedAward)).To(Succeed())
+		Expect(reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))).Error().ToNot(HaveOccurred())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		Expect(instance.Status.Awards).To(HaveLen(1))
+		oldAwardId := instance.Status.Awards[0].Id
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&replacedAward), &replacedAward)).To(Succeed())
+		replacedAward.Spec.Value = 100
+		Expect(k8sClient.Update(ctx, &replacedAward)).To(Succeed())
+		failingAward := v1alpha1.Award{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "b-failing",
+				Namespace: corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.AwardSpec{
+				CTFdName:        instance.Name,
+				ParticipantName: "deleted-participant",
+				Name:            "First blood",
+				Value:           10,
+			},
+		}
+		Expect(k8sClient.Create(ctx, &failingAward)).To(Succeed())
+
+		By("run the reconciler")
+		Expect(reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))).Error().To(HaveOccurred())
+
+		By("verify all postconditions")
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		Expect(instance.Status.Awards).To(ConsistOf(HaveField("Name", replacedAward.Name)))
+		newAwardId := instance.Status.Awards[0].Id
+		Expect(newAwardId).ToNot(Equal(oldAwardId))
+
+		ctfdAwards, err := ctfdClient.ListAwards(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ctfdAwards).To(ConsistOf(HaveField("Id", newAwardId)))
+		Expect(ctfdAwards[0].Value).To(Equal(100))
+	})
+
+	It("should report awards of missing participants", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		award := v1alpha1.Award{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.AwardSpec{
+				CTFdName:        instance.Name,
+				ParticipantName: "does-not-exist",
+				Name:            "Write-up",
+				Value:           50,
+			},
+		}
+		Expect(k8sClient.Create(ctx, &award)).To(Succeed())
+
+		By("run the reconciler")
+		Expect(reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))).Error().To(HaveOccurred())
+
+		By("verify all postconditions")
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		Expect(instance.Status.Awards).To(BeEmpty())
+		Expect(meta.IsStatusConditionFalse(instance.Status.Conditions, v1alpha1.ConditionTypeAwardsSynced)).To(BeTrue())
+		Expect(RecordedEvents()).To(ContainElement(HavePrefix("Warning AwardSyncFailed")))
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&award), &award)).To(Succeed())
+		Expect(award.Status.Id).To(BeZero())
+		syncedCondition := meta.FindStatusCondition(award.Status.Conditions, v1alpha1.ConditionTypeSynced)
+		Expect(syncedCondition).ToNot(BeNil())
+		Expect(syncedCondition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(syncedCondition.Reason).To(Equal("InvalidRecipient"))
+	})
+})
@@ -0,0 +1,80 @@
+package ctfd
+
+import (
+	"context"
+	"slices"
+
+	v1alpha2 "github.com/backbone81/ctf-challenge-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/backbone81/ctf-ui-operator/api/v1alpha1"
+	"github.com/backbone81/ctf-ui-operator/internal/ctfdapi"
+)
+
+// isUnmanagedChallenge returns true if the challenge in CTFd was not created or adopted by the operator. Those
+// challenges are recognized through the bookkeeping, which needs to be given from before any challenge was removed
+// from it.
+func (r *ChallengeDescriptionReconciler) isUnmanagedChallenge(challengeStatusBefore []v1alpha1.ChallengeDescriptionStatus, ctfd *v1alpha1.CTFd, ctfdChallenge ctfdapi.Challenge) bool {
+	return !r.statusExistsForCTFdChallenge(challengeStatusBefore, ctfdChallenge) &&
+		!r.statusExistsForCTFdChallenge(ctfd.Status.ChallengeDescriptions, ctfdChallenge)
+}
+
+// deletesUnmanagedChallenge returns true if the given unmanaged challenge is to be deleted according to the unmanaged
+// policy.
+func (r *ChallengeDescriptionReconciler) deletesUnmanagedChallenge(ctfd *v1alpha1.CTFd, ctfdChallenge ctfdapi.Challenge) bool {
+	return ctfd.Spec.ChallengeSync.GetUnmanagedPolicy() == v1alpha1.UnmanagedChallengePolicyDelete &&
+		ctfd.Spec.ChallengeSync.IsManagedCategory(ctfdChallenge.Category)
+}
+
+// findAdoptableChallenge returns the unmanaged challenge in CTFd with the same name and category as the desired
+// challenge. It returns false if the unmanaged policy does not allow for adopting challenges or if there is no such
+// challenge.
+func (r *ChallengeDescriptionReconciler) findAdoptableChallenge(ctfdChallenges []ctfdapi.Challenge, challengeStatusBefore []v1alpha1.ChallengeDescriptionStatus, ctfd *v1alpha1.CTFd, desiredChallenge ctfdapi.Challenge) (ctfdapi.Challenge, bool) {
+	if ctfd.Spec.ChallengeSync.GetUnmanagedPolicy() != v1alpha1.UnmanagedChallengePolicyAdopt ||
+		!ctfd.Spec.ChallengeSync.IsManagedCategory(desiredChallenge.Category) {
+		return ctfdapi.Challenge{}, false
+	}
+	index := slices.IndexFunc(ctfdChallenges, func(ctfdChallenge ctfdapi.Challenge) bool {
+		return ctfdChallenge.Name == desiredChallenge.Name &&
+			ctfdChallenge.Category == desiredChallenge.Category &&
+			r.isUnmanagedChallenge(challengeStatusBefore, ctfd, ctfdChallenge)
+	})
+	if index == -1 {
+		return ctfdapi.Challenge{}, false
+	}
+	return ctfdChallenges[index], true
+}
+
+// adoptChallenge takes over the given challenge in CTFd for the ChallengeDescription. The challenge keeps its id and
+// therefore its solves. Everything else is overwritten with the ChallengeDescription right away.
+func (r *ChallengeDescriptionReconciler) adoptChallenge(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdChallenge ctfdapi.Challenge, ctfd *v1alpha1.CTFd, k8sChallenge *v1alpha2.ChallengeDescription) error {
+	ctrl.LoggerFrom(ctx).Info(
+		"Adopting challenge",
+		"id", ctfdChallenge.Id,
+		"name", ctfdChallenge.Name,
+	)
+	ctfd.Status.ChallengeDescriptions = append(ctfd.Status.ChallengeDescriptions, v1alpha1.ChallengeDescriptionStatus{
+		Id:        ctfdChallenge.Id,
+		Name:      k8sChallenge.Name,
+		Namespace: k8sChallenge.Namespace,
+	})
+	challengeStatusIdx := len(ctfd.Status.ChallengeDescriptions) - 1
+	if err := r.GetClient().Status().Update(ctx, ctfd); err != nil {
+		return err
+	}
+	r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "ChallengeAdopted", "Adopted challenge %q with id %d for ChallengeDescription %s/%s", ctfdChallenge.Name, ctfdChallenge.Id, k8sChallenge.Namespace, k8sChallenge.Name)
+
+	// The CTFd challenge list endpoint does not return all fields. We need to call the get endpoint to get them.
+	fullCTFdChallenge, err := ctfdClient.GetChallenge(ctx, ctfdChallenge.Id)
+	if err != nil {
+		return err
+	}
+	challengeStatus := &ctfd.Status.ChallengeDescriptions[challengeStatusIdx]
+	if err := r.updateExistingChallenge(ctx, ctfdClient, fullCTFdChallenge, ctfd, challengeStatus, k8sChallenge); err != nil {
+		return err
+	}
+	challengeStatus.ObservedGeneration = k8sChallenge.Generation
+	challengeStatus.ObservedAnnotations = getAnnotationsHash(k8sChallenge)
+	return nil
+}
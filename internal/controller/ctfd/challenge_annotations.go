@@ -0,0 +1,68 @@
+package ctfd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	v1alpha2 "github.com/backbone81/ctf-challenge-operator/api/v1alpha1"
+
+	"github.com/backbone81/ctf-ui-operator/api/v1alpha1"
+)
+
+// getJSONAnnotation decodes the JSON content of the given annotation into the target. It returns false if the
+// annotation is not set.
+func getJSONAnnotation(k8sChallenge *v1alpha2.ChallengeDescription, annotation string, target any) (bool, error) {
+	value, ok := k8sChallenge.Annotations[annotation]
+	if !ok {
+		return false, nil
+	}
+	if err := json.Unmarshal([]byte(value), target); err != nil {
+		return false, fmt.Errorf("parsing annotation %s: %w", annotation, err)
+	}
+	return true, nil
+}
+
+// getListAnnotation returns the entries of the given comma separated annotation. Surrounding whitespace, empty entries
+// and duplicates are removed. It returns false if the annotation is not set.
+func getListAnnotation(k8sChallenge *v1alpha2.ChallengeDescription, annotation string) ([]string, bool) {
+	value, ok := k8sChallenge.Annotations[annotation]
+	if !ok {
+		return nil, false
+	}
+	var result []string
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if len(entry) == 0 || slices.Contains(result, entry) {
+			continue
+		}
+		result = append(result, entry)
+	}
+	return result, true
+}
+
+// getAnnotationsHash returns a hash over all annotations of the ChallengeDescription which are interpreted by this
+// operator. Other annotations are ignored, as they do not influence the challenge in CTFd. The release of a challenge
+// is part of the hash, as it changes the challenge in CTFd without any change to the ChallengeDescription.
+func getAnnotationsHash(k8sChallenge *v1alpha2.ChallengeDescription) string {
+	hash := sha256.New()
+	if releaseTime, err := getReleaseTime(k8sChallenge); err == nil && releaseTime != nil && !time.Now().Before(*releaseTime) {
+		hash.Write([]byte("released"))
+		hash.Write([]byte{0})
+	}
+	for _, key := range slices.Sorted(maps.Keys(k8sChallenge.Annotations)) {
+		if !strings.HasPrefix(key, v1alpha1.ChallengeAnnotationPrefix) {
+			continue
+		}
+		hash.Write([]byte(key))
+		hash.Write([]byte{0})
+		hash.Write([]byte(k8sChallenge.Annotations[key]))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil)[:8])
+}
@@ -0,0 +1,228 @@
+package ctfd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+
+	v1alpha2 "github.com/backbone81/ctf-challenge-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/backbone81/ctf-ui-operator/api/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups=core.ctf.backbone81,resources=challengeinstances,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch
+
+// challengeInstanceNameField is the field the ChallengeInstances are indexed by in the cache. The API server supports
+// the field for all resources, which allows the same list call without the cache.
+const challengeInstanceNameField = "metadata.name"
+
+// connectionInfoData is the data the connection info template is rendered with.
+type connectionInfoData struct {
+	// Host is the host name or IP address players connect to.
+	Host string
+
+	// Port is the port players connect to.
+	Port int32
+
+	// Namespace is the namespace the ChallengeInstance is running in.
+	Namespace string
+}
+
+// renderConnectionInfos renders the connection info template of the given ChallengeDescriptions with the endpoint of
+// their ChallengeInstance. The result replaces the connection info annotation on our copy of the ChallengeDescription.
+// Changes to the endpoint are therefore handled like changes to the annotation and are not reported as drift.
+func (r *ChallengeDescriptionReconciler) renderConnectionInfos(ctx context.Context, k8sChallenges []v1alpha2.ChallengeDescription) error {
+	for i := range k8sChallenges {
+		k8sChallenge := &k8sChallenges[i]
+		connectionInfoTemplate, ok := k8sChallenge.Annotations[v1alpha1.ChallengeConnectionInfoTemplateAnnotation]
+		if !ok {
+			continue
+		}
+		parsedTemplate, err := template.New("connection-info").Option("missingkey=error").Parse(connectionInfoTemplate)
+		if err != nil {
+			return fmt.Errorf("parsing annotation %s: %w", v1alpha1.ChallengeConnectionInfoTemplateAnnotation, err)
+		}
+
+		data, err := r.getConnectionInfoData(ctx, k8sChallenge)
+		if err != nil {
+			return err
+		}
+		if data == nil {
+			// There is no endpoint yet. We will be triggered again when the endpoint shows up.
+			continue
+		}
+
+		var connectionInfo strings.Builder
+		if err := parsedTemplate.Execute(&connectionInfo, data); err != nil {
+			return fmt.Errorf("rendering annotation %s: %w", v1alpha1.ChallengeConnectionInfoTemplateAnnotation, err)
+		}
+		k8sChallenge.Annotations[v1alpha1.ChallengeConnectionInfoAnnotation] = connectionInfo.String()
+	}
+	return nil
+}
+
+// getConnectionInfoData returns the endpoint of the oldest ChallengeInstance of the given ChallengeDescription which
+// provides an endpoint. Using the oldest instance keeps the connection info stable when additional instances are
+// started. It returns nil if no endpoint is available.
+func (r *ChallengeDescriptionReconciler) getConnectionInfoData(ctx context.Context, k8sChallenge *v1alpha2.ChallengeDescription) (*connectionInfoData, error) {
+	var challengeInstanceList v1alpha2.ChallengeInstanceList
+	if err := r.GetClient().List(ctx, &challengeInstanceList, client.InNamespace(k8sChallenge.Namespace)); err != nil {
+		return nil, err
+	}
+	challengeInstances := slices.DeleteFunc(challengeInstanceList.Items, func(challengeInstance v1alpha2.ChallengeInstance) bool {
+		return challengeInstance.Spec.ChallengeDescriptionName != k8sChallenge.Name ||
+			!challengeInstance.DeletionTimestamp.IsZero()
+	})
+	slices.SortFunc(challengeInstances, func(lhs v1alpha2.ChallengeInstance, rhs v1alpha2.ChallengeInstance) int {
+		if result := lhs.CreationTimestamp.Compare(rhs.CreationTimestamp.Time); result != 0 {
+			return result
+		}
+		return strings.Compare(lhs.Name, rhs.Name)
+	})
+
+	for _, challengeInstance := range challengeInstances {
+		// The challenge operator places the manifests of every ChallengeInstance into a namespace with the name of
+		// the ChallengeInstance.
+		data, err := r.getInstanceEndpoint(ctx, challengeInstance.Name)
+		if err != nil {
+			return nil, err
+		}
+		if data != nil {
+			return data, nil
+		}
+	}
+	return nil, nil
+}
+
+// getInstanceEndpoint returns the endpoint players connect to for the ChallengeInstance running in the given
+// namespace. Ingresses take precedence over services of type LoadBalancer. It returns nil if no endpoint is available.
+func (r *ChallengeDescriptionReconciler) getInstanceEndpoint(ctx context.Context, namespace string) (*connectionInfoData, error) {
+	var ingressList networkingv1.IngressList
+	if err := r.GetClient().List(ctx, &ingressList, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	for _, ingress := range ingressList.Items {
+		for _, rule := range ingress.Spec.Rules {
+			if len(rule.Host) == 0 {
+				continue
+			}
+			port := int32(80)
+			if slices.ContainsFunc(ingress.Spec.TLS, func(tls networkingv1.IngressTLS) bool {
+				return slices.Contains(tls.Hosts, rule.Host)
+			}) {
+				port = 443
+			}
+			return &connectionInfoData{
+				Host:      rule.Host,
+				Port:      port,
+				Namespace: namespace,
+			}, nil
+		}
+	}
+
+	var serviceList corev1.ServiceList
+	if err := r.GetClient().List(ctx, &serviceList, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	for _, service := range serviceList.Items {
+		if service.Spec.Type != corev1.ServiceTypeLoadBalancer || len(service.Spec.Ports) == 0 {
+			continue
+		}
+		for _, loadBalancerIngress := range service.Status.LoadBalancer.Ingress {
+			host := loadBalancerIngress.Hostname
+			if len(host) == 0 {
+				host = loadBalancerIngress.IP
+			}
+			if len(host) == 0 {
+				continue
+			}
+			return &connectionInfoData{
+				Host:      host,
+				Port:      service.Spec.Ports[0].Port,
+				Namespace: namespace,
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+// instanceEndpointPredicate only passes Ingresses and Services of type LoadBalancer, as no other resource provides the
+// endpoint of a ChallengeInstance. Services changing their type are passed as well.
+func (r *ChallengeDescriptionReconciler) instanceEndpointPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return r.isInstanceEndpoint(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return r.isInstanceEndpoint(e.ObjectOld) || r.isInstanceEndpoint(e.ObjectNew)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return r.isInstanceEndpoint(e.Object)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return r.isInstanceEndpoint(e.Object)
+		},
+	}
+}
+
+func (r *ChallengeDescriptionReconciler) isInstanceEndpoint(obj client.Object) bool {
+	service, ok := obj.(*corev1.Service)
+	return !ok || service.Spec.Type == corev1.ServiceTypeLoadBalancer
+}
+
+// SetupIndexes indexes the ChallengeInstances by their name. The endpoint of a ChallengeInstance is located in the
+// namespace with the name of the ChallengeInstance, while the ChallengeInstance itself can be in any namespace.
+func (r *ChallengeDescriptionReconciler) SetupIndexes(ctx context.Context, indexer client.FieldIndexer) error {
+	return indexer.IndexField(ctx, &v1alpha2.ChallengeInstance{}, challengeInstanceNameField, func(obj client.Object) []string {
+		return []string{obj.GetName()}
+	})
+}
+
+// MapInstanceEndpointToCTFds returns reconcile requests for all CTFd instances which are reconciling the
+// ChallengeDescription of the ChallengeInstance the given Service or Ingress belongs to. ChallengeDescriptions without
+// a connection info template do not depend on the endpoint and are skipped.
+func (r *ChallengeDescriptionReconciler) MapInstanceEndpointToCTFds(ctx context.Context, endpoint client.Object) []reconcile.Request {
+	// The challenge operator places the manifests of every ChallengeInstance into a namespace with the name of the
+	// ChallengeInstance.
+	var challengeInstanceList v1alpha2.ChallengeInstanceList
+	if err := r.GetClient().List(ctx, &challengeInstanceList, client.MatchingFields{
+		challengeInstanceNameField: endpoint.GetNamespace(),
+	}); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Listing ChallengeInstances for endpoint failed.")
+		return nil
+	}
+
+	var result []reconcile.Request
+	for _, challengeInstance := range challengeInstanceList.Items {
+		// ChallengeInstances are located in the namespace of their ChallengeDescription.
+		var challengeDescription v1alpha2.ChallengeDescription
+		if err := r.GetClient().Get(ctx, client.ObjectKey{
+			Name:      challengeInstance.Spec.ChallengeDescriptionName,
+			Namespace: challengeInstance.Namespace,
+		}, &challengeDescription); err != nil {
+			if err := client.IgnoreNotFound(err); err != nil {
+				ctrl.LoggerFrom(ctx).Error(err, "Getting ChallengeDescription for endpoint failed.")
+			}
+			continue
+		}
+		if _, ok := challengeDescription.Annotations[v1alpha1.ChallengeConnectionInfoTemplateAnnotation]; !ok {
+			continue
+		}
+		for _, request := range r.MapChallengeDescriptionToCTFds(ctx, &challengeDescription) {
+			if !slices.Contains(result, request) {
+				result = append(result, request)
+			}
+		}
+	}
+	return result
+}
@@ -2,11 +2,28 @@ package ctfd
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	v1alpha2 "github.com/backbone81/ctf-challenge-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/backbone81/ctf-ui-operator/api/v1alpha1"
 	"github.com/backbone81/ctf-ui-operator/internal/ctfdapi"
@@ -14,16 +31,25 @@ import (
 )
 
 // +kubebuilder:rbac:groups=core.ctf.backbone81,resources=challengedescriptions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
 
 // ChallengeDescriptionReconciler is responsible for reconciling ChallengeDescription resources into the instance.
 type ChallengeDescriptionReconciler struct {
 	utils.DefaultSubReconciler
 	ctfdEndpoint CTFdEndpointStrategy
+
+	// minioEndpoint is optional. It is only required for challenge files which are stored in Minio.
+	minioEndpoint MinioEndpointStrategy
 }
 
-func NewChallengeDescriptionReconciler(client client.Client, options ...SubReconcilerOption) *ChallengeDescriptionReconciler {
+var (
+	_ utils.SubFinalizer[*v1alpha1.CTFd] = (*ChallengeDescriptionReconciler)(nil)
+	_ utils.SubIndexer                   = (*ChallengeDescriptionReconciler)(nil)
+)
+
+func NewChallengeDescriptionReconciler(client client.Client, recorder record.EventRecorder, options ...SubReconcilerOption) *ChallengeDescriptionReconciler {
 	result := &ChallengeDescriptionReconciler{
-		DefaultSubReconciler: utils.NewDefaultSubReconciler(client),
+		DefaultSubReconciler: utils.NewDefaultSubReconciler(client, recorder),
 	}
 	for _, option := range options {
 		option(result)
@@ -35,10 +61,95 @@ func NewChallengeDescriptionReconciler(client client.Client, options ...SubRecon
 	return result
 }
 
+func (r *ChallengeDescriptionReconciler) SetupWithManager(ctrlBuilder *builder.Builder) *builder.Builder {
+	return ctrlBuilder.Watches(
+		&v1alpha2.ChallengeDescription{},
+		handler.EnqueueRequestsFromMapFunc(r.MapChallengeDescriptionToCTFds),
+	).Watches(
+		&corev1.Namespace{},
+		handler.EnqueueRequestsFromMapFunc(r.MapNamespaceToCTFds),
+		builder.WithPredicates(predicate.LabelChangedPredicate{}),
+	).Watches(
+		// ChallengeInstances are located in the namespace of their ChallengeDescription, so they are mapped the same
+		// way.
+		&v1alpha2.ChallengeInstance{},
+		handler.EnqueueRequestsFromMapFunc(r.MapChallengeDescriptionToCTFds),
+	).Watches(
+		&corev1.ConfigMap{},
+		handler.EnqueueRequestsFromMapFunc(r.MapFileSourceToCTFds),
+	).Watches(
+		&corev1.Secret{},
+		handler.EnqueueRequestsFromMapFunc(r.MapFileSourceToCTFds),
+	).Watches(
+		&corev1.Service{},
+		handler.EnqueueRequestsFromMapFunc(r.MapInstanceEndpointToCTFds),
+		builder.WithPredicates(r.instanceEndpointPredicate()),
+	).Watches(
+		&networkingv1.Ingress{},
+		handler.EnqueueRequestsFromMapFunc(r.MapInstanceEndpointToCTFds),
+	)
+}
+
+// MapChallengeDescriptionToCTFds returns reconcile requests for all CTFd instances which are reconciling
+// ChallengeDescription resources from the namespace the given ChallengeDescription is located in.
+// The challenge selector is not taken into account, as ChallengeDescriptions which stop matching need to be removed.
+func (r *ChallengeDescriptionReconciler) MapChallengeDescriptionToCTFds(ctx context.Context, challengeDescription client.Object) []reconcile.Request {
+	var ctfdList v1alpha1.CTFdList
+	if err := r.GetClient().List(ctx, &ctfdList); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Listing CTFd instances for ChallengeDescription failed.")
+		return nil
+	}
+
+	var result []reconcile.Request
+	for _, ctfd := range ctfdList.Items {
+		if !r.reconcilesChallenges(&ctfd) {
+			continue
+		}
+		namespaces, err := r.resolveChallengeNamespaces(ctx, &ctfd)
+		if err != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "Resolving challenge namespaces for ChallengeDescription failed.", "ctfd", ctfd.Name)
+			continue
+		}
+		if !slices.Contains(namespaces, challengeDescription.GetNamespace()) {
+			continue
+		}
+		result = append(result, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&ctfd),
+		})
+	}
+	return result
+}
+
+// MapNamespaceToCTFds returns reconcile requests for all CTFd instances which are selecting challenge namespaces by
+// label. Those need to pick up namespaces which start or stop matching.
+func (r *ChallengeDescriptionReconciler) MapNamespaceToCTFds(ctx context.Context, namespace client.Object) []reconcile.Request {
+	var ctfdList v1alpha1.CTFdList
+	if err := r.GetClient().List(ctx, &ctfdList); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Listing CTFd instances for namespace failed.")
+		return nil
+	}
+
+	var result []reconcile.Request
+	for _, ctfd := range ctfdList.Items {
+		if ctfd.Spec.ChallengeNamespaceSelector == nil {
+			continue
+		}
+		result = append(result, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&ctfd),
+		})
+	}
+	return result
+}
+
+func (r *ChallengeDescriptionReconciler) Blocking() bool {
+	// A broken challenge must not prevent the other sub-reconcilers from running.
+	return false
+}
+
 func (r *ChallengeDescriptionReconciler) Reconcile(ctx context.Context, ctfd *v1alpha1.CTFd) (ctrl.Result, error) {
-	if ctfd.Spec.ChallengeNamespace == nil {
+	if !r.reconcilesChallenges(ctfd) {
 		ctrl.LoggerFrom(ctx).V(1).Info("No challenge namespace provided, skipping ChallengeDescriptionReconciler.")
-		return ctrl.Result{}, nil
+		return ctrl.Result{}, r.RemoveCondition(ctx, ctfd, v1alpha1.ConditionTypeChallengesSynced)
 	}
 	if !ctfd.Status.Ready {
 		// The CTFd instance is not ready. We try again later when the instance is up and running. The next reconcile
@@ -62,41 +173,169 @@ func (r *ChallengeDescriptionReconciler) Reconcile(ctx context.Context, ctfd *v1
 		return ctrl.Result{}, err
 	}
 
-	if err := r.reconcileChallenges(ctx, ctfdClient, ctfd); err != nil {
-		return ctrl.Result{}, err
+	result, err := r.reconcileChallenges(ctx, ctfdClient, ctfd)
+	if err != nil {
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeWarning, "ChallengeSyncFailed", "Failed to sync challenges: %s", err)
+		return ctrl.Result{}, errors.Join(err, r.SetCondition(
+			ctx,
+			ctfd,
+			v1alpha1.ConditionTypeChallengesSynced,
+			metav1.ConditionFalse,
+			"ChallengeSyncFailed",
+			"Failed to sync challenges, check the ChallengeSyncFailed events for details.",
+		))
 	}
-	return ctrl.Result{}, nil
+	if interval := ctfd.Spec.ChallengeSync.Interval; interval != nil && (result.RequeueAfter == 0 || interval.Duration < result.RequeueAfter) {
+		// We need to check for drift periodically, as changes in CTFd do not trigger any reconcile.
+		result.RequeueAfter = interval.Duration
+	}
+
+	driftedChallenges := r.countDriftedChallenges(ctfd)
+	if driftedChallenges != 0 && ctfd.Spec.ChallengeSync.GetPolicy() == v1alpha1.ChallengeSyncPolicyReportOnly {
+		return result, r.SetCondition(
+			ctx,
+			ctfd,
+			v1alpha1.ConditionTypeChallengesSynced,
+			metav1.ConditionFalse,
+			"DriftDetected",
+			fmt.Sprintf("%d of %d challenges drifted from their ChallengeDescription.", driftedChallenges, len(ctfd.Status.ChallengeDescriptions)),
+		)
+	}
+	return result, r.SetCondition(
+		ctx,
+		ctfd,
+		v1alpha1.ConditionTypeChallengesSynced,
+		metav1.ConditionTrue,
+		"ChallengesSynced",
+		fmt.Sprintf("%d challenges are synced.", len(ctfd.Status.ChallengeDescriptions)),
+	)
 }
 
-func (r *ChallengeDescriptionReconciler) reconcileChallenges(ctx context.Context, ctfdClient *ctfdapi.Client, ctfd *v1alpha1.CTFd) error {
+// reconcileChallenges syncs all ChallengeDescriptions into CTFd. The result requeues at the next release time of a
+// challenge, as time passing does not trigger any reconcile.
+func (r *ChallengeDescriptionReconciler) reconcileChallenges(ctx context.Context, ctfdClient *ctfdapi.Client, ctfd *v1alpha1.CTFd) (ctrl.Result, error) {
 	ctfdChallenges, err := ctfdClient.ListChallenges(ctx)
 	if err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 
-	var challengeDescriptionList v1alpha2.ChallengeDescriptionList
-	if err := r.GetClient().List(
-		ctx,
-		&challengeDescriptionList,
-		client.InNamespace(r.resolveChallengeNamespace(ctfd)),
-	); err != nil {
-		return err
+	challengeSelector, err := r.resolveChallengeSelector(ctfd)
+	if err != nil {
+		return ctrl.Result{}, err
 	}
-	k8sChallenges := challengeDescriptionList.Items
 
+	namespaces, err := r.resolveChallengeNamespaces(ctx, ctfd)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	k8sChallenges, err := r.listK8sChallenges(ctx, namespaces)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	// ChallengeDescriptions which are being deleted or which do not match the selector are treated as if they are
+	// already gone. Their challenges are removed from CTFd through the bookkeeping.
+	k8sChallenges = slices.DeleteFunc(k8sChallenges, func(k8sChallenge v1alpha2.ChallengeDescription) bool {
+		return !k8sChallenge.DeletionTimestamp.IsZero() || !challengeSelector.Matches(labels.Set(k8sChallenge.Labels))
+	})
+	// Rendering the connection info only changes our copy of the ChallengeDescriptions, which is never written back.
+	if err := r.renderConnectionInfos(ctx, k8sChallenges); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	challengeStatusBefore := ctfd.Status.DeepCopy().ChallengeDescriptions
 	r.cleanupChallengeStatus(ctfd, k8sChallenges, ctfdChallenges)
 
 	if err := r.updateExistingChallenges(ctx, ctfdClient, ctfdChallenges, ctfd, k8sChallenges); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.createMissingChallenges(ctx, ctfdClient, ctfdChallenges, challengeStatusBefore, ctfd, k8sChallenges); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Failing requirements must not prevent the bookkeeping from being persisted. We report the error at the end.
+	requirementsErr := r.reconcileRequirements(ctx, ctfdClient, ctfd, k8sChallenges)
+
+	if err := r.deleteObsoleteChallenges(ctx, ctfdClient, ctfdChallenges, challengeStatusBefore, ctfd); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !equality.Semantic.DeepEqual(challengeStatusBefore, ctfd.Status.ChallengeDescriptions) {
+		if err := r.GetClient().Status().Update(ctx, ctfd); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if requirementsErr != nil {
+		return ctrl.Result{}, requirementsErr
+	}
+
+	now := time.Now()
+	if nextReleaseTime := getNextReleaseTime(k8sChallenges, now); nextReleaseTime != nil {
+		return ctrl.Result{RequeueAfter: nextReleaseTime.Sub(now)}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// Finalize removes all challenges of the CTFd instance which were created by the operator.
+func (r *ChallengeDescriptionReconciler) Finalize(ctx context.Context, ctfd *v1alpha1.CTFd) (ctrl.Result, error) {
+	if err := r.deleteAllChallenges(ctx, ctfd); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if len(ctfd.Status.ChallengeDescriptions) != 0 {
+		ctfd.Status.ChallengeDescriptions = nil
+		if err := r.GetClient().Status().Update(ctx, ctfd); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *ChallengeDescriptionReconciler) deleteAllChallenges(ctx context.Context, ctfd *v1alpha1.CTFd) error {
+	if !ctfd.Status.Ready {
+		// Without a running CTFd instance there is nothing we can remove.
+		ctrl.LoggerFrom(ctx).V(1).Info("CTFd is not ready, skipping challenge removal.")
+		return nil
+	}
+
+	adminDetails, err := GetAdminDetails(ctx, r.GetClient(), ctfd)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if len(adminDetails.AccessToken) == 0 {
+		ctrl.LoggerFrom(ctx).V(1).Info("No access token available, skipping challenge removal.")
+		return nil
+	}
+
+	endpoint, err := r.ctfdEndpoint.GetEndpoint(ctx, ctfd)
+	if err != nil {
 		return err
 	}
 
-	if err := r.createMissingChallenges(ctx, ctfdClient, ctfd, k8sChallenges); err != nil {
+	ctfdClient, err := ctfdapi.NewClient(endpoint, adminDetails.AccessToken)
+	if err != nil {
 		return err
 	}
 
-	if err := r.deleteObsoleteChallenges(ctx, ctfdClient, ctfdChallenges, ctfd); err != nil {
+	ctfdChallenges, err := ctfdClient.ListChallenges(ctx)
+	if err != nil {
 		return err
 	}
+	for _, ctfdChallenge := range ctfdChallenges {
+		if !r.statusExistsForCTFdChallenge(ctfd.Status.ChallengeDescriptions, ctfdChallenge) {
+			continue
+		}
+		ctrl.LoggerFrom(ctx).Info(
+			"Deleting challenge",
+			"id", ctfdChallenge.Id,
+			"name", ctfdChallenge.Name,
+		)
+		if err := ctfdapi.IgnoreNotFound(ctfdClient.DeleteChallenge(ctx, ctfdChallenge.Id)); err != nil {
+			return err
+		}
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "ChallengeDeleted", "Deleted challenge %q with id %d", ctfdChallenge.Name, ctfdChallenge.Id)
+	}
 	return nil
 }
 
@@ -134,8 +373,9 @@ func (r *ChallengeDescriptionReconciler) ctfdChallengeExists(ctfdChallenges []ct
 }
 
 func (r *ChallengeDescriptionReconciler) updateExistingChallenges(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdChallenges []ctfdapi.Challenge, ctfd *v1alpha1.CTFd, k8sChallenges []v1alpha2.ChallengeDescription) error {
-	for challengeStatusIdx, challengeStatus := range ctfd.Status.ChallengeDescriptions {
-		ctfdChallengeIndex := r.getCTFdChallengeIndex(ctfdChallenges, challengeStatus)
+	for challengeStatusIdx := range ctfd.Status.ChallengeDescriptions {
+		challengeStatus := &ctfd.Status.ChallengeDescriptions[challengeStatusIdx]
+		ctfdChallengeIndex := r.getCTFdChallengeIndex(ctfdChallenges, *challengeStatus)
 		ctfdChallenge := ctfdChallenges[ctfdChallengeIndex]
 
 		// The CTFd challenge list endpoint does not return the description. We need to call the get endpoint to get
@@ -146,37 +386,79 @@ func (r *ChallengeDescriptionReconciler) updateExistingChallenges(ctx context.Co
 		}
 		ctfdChallenge = fullCTFdChallenge
 
-		k8sChallengeIndex := r.getK8sChallengeIndex(k8sChallenges, challengeStatus)
+		k8sChallengeIndex := r.getK8sChallengeIndex(k8sChallenges, *challengeStatus)
 		k8sChallenge := k8sChallenges[k8sChallengeIndex]
 
-		// We need to reconcile hints and flags before we exit early on no changes.
-		if err := r.reconcileHints(ctx, ctfdClient, ctfdChallenge, ctfd, &ctfd.Status.ChallengeDescriptions[challengeStatusIdx], k8sChallenge.Spec.Hints); err != nil {
-			return err
-		}
-		if err := r.reconcileFlag(ctx, ctfdClient, ctfdChallenge, &k8sChallenge); err != nil {
-			return err
+		// Changes to the ChallengeDescription are always written to CTFd. Only differences which were introduced in
+		// CTFd itself are subject to the challenge sync policy.
+		if challengeStatus.ObservedGeneration == k8sChallenge.Generation &&
+			challengeStatus.ObservedAnnotations == getAnnotationsHash(&k8sChallenge) {
+			drift, err := r.detectDrift(ctx, ctfdClient, ctfdChallenge, *challengeStatus, &k8sChallenge)
+			if err != nil {
+				return err
+			}
+			if len(drift) != 0 && ctfd.Spec.ChallengeSync.GetPolicy() != v1alpha1.ChallengeSyncPolicyEnforce {
+				r.recordDrift(ctfd, challengeStatus, ctfdChallenge, drift)
+				continue
+			}
+			if len(drift) != 0 {
+				r.GetRecorder().Eventf(ctfd, corev1.EventTypeWarning, "DriftCorrected", "Corrected drift in %s of challenge %q with id %d", strings.Join(drift, ", "), ctfdChallenge.Name, ctfdChallenge.Id)
+			}
 		}
 
-		if ctfdChallenge.Name == k8sChallenge.Spec.Title &&
-			ctfdChallenge.Description == k8sChallenge.Spec.Description &&
-			ctfdChallenge.Value == k8sChallenge.Spec.Value &&
-			ctfdChallenge.Category == k8sChallenge.Spec.Category {
-			continue
+		if err := r.updateExistingChallenge(ctx, ctfdClient, ctfdChallenge, ctfd, challengeStatus, &k8sChallenge); err != nil {
+			return err
 		}
+		challengeStatus.ObservedGeneration = k8sChallenge.Generation
+		challengeStatus.ObservedAnnotations = getAnnotationsHash(&k8sChallenge)
+		challengeStatus.Drift = nil
+	}
+	return nil
+}
 
-		ctrl.LoggerFrom(ctx).Info(
-			"Updating challenge",
-			"id", ctfdChallenge.Id,
-			"name", k8sChallenge.Spec.Title,
-		)
-		ctfdChallenge.Name = k8sChallenge.Spec.Title
-		ctfdChallenge.Description = k8sChallenge.Spec.Description
-		ctfdChallenge.Value = k8sChallenge.Spec.Value
-		ctfdChallenge.Category = k8sChallenge.Spec.Category
-		if _, err := ctfdClient.UpdateChallenge(ctx, ctfdChallenge); err != nil {
+func (r *ChallengeDescriptionReconciler) updateExistingChallenge(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdChallenge ctfdapi.Challenge, ctfd *v1alpha1.CTFd, challengeStatus *v1alpha1.ChallengeDescriptionStatus, k8sChallenge *v1alpha2.ChallengeDescription) error {
+	desiredChallenge, err := r.getDesiredChallenge(k8sChallenge)
+	if err != nil {
+		return err
+	}
+	if ctfdChallenge.Type != desiredChallenge.Type {
+		ctfdChallenge, err = r.recreateChallenge(ctx, ctfdClient, ctfdChallenge, desiredChallenge, ctfd, challengeStatus)
+		if err != nil {
 			return err
 		}
 	}
+
+	// We need to reconcile hints, flags, tags, topics and files before we exit early on no changes.
+	if err := r.reconcileHints(ctx, ctfdClient, ctfdChallenge, ctfd, challengeStatus, k8sChallenge); err != nil {
+		return err
+	}
+	if err := r.reconcileFlags(ctx, ctfdClient, ctfdChallenge, ctfd, k8sChallenge); err != nil {
+		return err
+	}
+	if err := r.reconcileTags(ctx, ctfdClient, ctfdChallenge, ctfd, k8sChallenge); err != nil {
+		return err
+	}
+	if err := r.reconcileTopics(ctx, ctfdClient, ctfdChallenge, ctfd, k8sChallenge); err != nil {
+		return err
+	}
+	if err := r.reconcileFiles(ctx, ctfdClient, ctfdChallenge, ctfd, challengeStatus, k8sChallenge); err != nil {
+		return err
+	}
+
+	if len(r.getChangedChallengeFields(ctfdChallenge, desiredChallenge)) == 0 {
+		return nil
+	}
+
+	ctrl.LoggerFrom(ctx).Info(
+		"Updating challenge",
+		"id", ctfdChallenge.Id,
+		"name", k8sChallenge.Spec.Title,
+	)
+	r.applyDesiredChallenge(&ctfdChallenge, desiredChallenge)
+	if _, err := ctfdClient.UpdateChallenge(ctx, ctfdChallenge); err != nil {
+		return err
+	}
+	r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "ChallengeUpdated", "Updated challenge %q with id %d", ctfdChallenge.Name, ctfdChallenge.Id)
 	return nil
 }
 
@@ -191,39 +473,59 @@ func (r *ChallengeDescriptionReconciler) statusExistsForK8sChallenge(challengeSt
 	return r.getStatusIndexForK8sChallenge(challengeStatus, k8sChallenge) != -1
 }
 
-func (r *ChallengeDescriptionReconciler) createMissingChallenges(ctx context.Context, ctfdClient *ctfdapi.Client, ctfd *v1alpha1.CTFd, k8sChallenges []v1alpha2.ChallengeDescription) error {
+// createMissingChallenges creates challenges for all ChallengeDescriptions which are not part of the bookkeeping. With
+// the adopt policy for unmanaged challenges, matching challenges which already exist in CTFd are taken over instead.
+func (r *ChallengeDescriptionReconciler) createMissingChallenges(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdChallenges []ctfdapi.Challenge, challengeStatusBefore []v1alpha1.ChallengeDescriptionStatus, ctfd *v1alpha1.CTFd, k8sChallenges []v1alpha2.ChallengeDescription) error {
 	missingChallenges := make([]v1alpha2.ChallengeDescription, len(k8sChallenges))
 	copy(missingChallenges, k8sChallenges)
 	missingChallenges = slices.DeleteFunc(missingChallenges, func(k8sChallenge v1alpha2.ChallengeDescription) bool {
 		return r.statusExistsForK8sChallenge(ctfd.Status.ChallengeDescriptions, k8sChallenge)
 	})
 	for _, k8sChallenge := range missingChallenges {
+		desiredChallenge, err := r.getDesiredChallenge(&k8sChallenge)
+		if err != nil {
+			return err
+		}
+		if adoptableChallenge, ok := r.findAdoptableChallenge(ctfdChallenges, challengeStatusBefore, ctfd, desiredChallenge); ok {
+			if err := r.adoptChallenge(ctx, ctfdClient, adoptableChallenge, ctfd, &k8sChallenge); err != nil {
+				return err
+			}
+			continue
+		}
+
 		ctrl.LoggerFrom(ctx).Info(
 			"Creating challenge",
 			"name", k8sChallenge.Spec.Title,
 		)
-		ctfdChallenge, err := ctfdClient.CreateChallenge(ctx, ctfdapi.Challenge{
-			Name:        k8sChallenge.Spec.Title,
-			Description: k8sChallenge.Spec.Description,
-			Value:       k8sChallenge.Spec.Value,
-			Category:    k8sChallenge.Spec.Category,
-		})
+		ctfdChallenge, err := ctfdClient.CreateChallenge(ctx, desiredChallenge)
 		if err != nil {
 			return err
 		}
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "ChallengeCreated", "Created challenge %q with id %d", ctfdChallenge.Name, ctfdChallenge.Id)
 		ctfd.Status.ChallengeDescriptions = append(ctfd.Status.ChallengeDescriptions, v1alpha1.ChallengeDescriptionStatus{
-			Id:        ctfdChallenge.Id,
-			Name:      k8sChallenge.Name,
-			Namespace: k8sChallenge.Namespace,
+			Id:                  ctfdChallenge.Id,
+			Name:                k8sChallenge.Name,
+			Namespace:           k8sChallenge.Namespace,
+			ObservedGeneration:  k8sChallenge.Generation,
+			ObservedAnnotations: getAnnotationsHash(&k8sChallenge),
 		})
 		challengeStatusIdx := len(ctfd.Status.ChallengeDescriptions) - 1
 		if err := r.GetClient().Status().Update(ctx, ctfd); err != nil {
 			return err
 		}
-		if err := r.reconcileHints(ctx, ctfdClient, ctfdChallenge, ctfd, &ctfd.Status.ChallengeDescriptions[challengeStatusIdx], k8sChallenge.Spec.Hints); err != nil {
+		if err := r.reconcileHints(ctx, ctfdClient, ctfdChallenge, ctfd, &ctfd.Status.ChallengeDescriptions[challengeStatusIdx], &k8sChallenge); err != nil {
+			return err
+		}
+		if err := r.reconcileFlags(ctx, ctfdClient, ctfdChallenge, ctfd, &k8sChallenge); err != nil {
+			return err
+		}
+		if err := r.reconcileTags(ctx, ctfdClient, ctfdChallenge, ctfd, &k8sChallenge); err != nil {
+			return err
+		}
+		if err := r.reconcileTopics(ctx, ctfdClient, ctfdChallenge, ctfd, &k8sChallenge); err != nil {
 			return err
 		}
-		if err := r.reconcileFlag(ctx, ctfdClient, ctfdChallenge, &k8sChallenge); err != nil {
+		if err := r.reconcileFiles(ctx, ctfdClient, ctfdChallenge, ctfd, &ctfd.Status.ChallengeDescriptions[challengeStatusIdx], &k8sChallenge); err != nil {
 			return err
 		}
 	}
@@ -240,11 +542,20 @@ func (r *ChallengeDescriptionReconciler) statusExistsForCTFdChallenge(challengeS
 	return r.getStatusIndexForCTFdChallenge(challengeStatus, ctfdChallenge) != -1
 }
 
-func (r *ChallengeDescriptionReconciler) deleteObsoleteChallenges(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdChallenges []ctfdapi.Challenge, ctfd *v1alpha1.CTFd) error {
+// deleteObsoleteChallenges deletes all challenges which are not part of the bookkeeping anymore. Challenges which were
+// never part of the bookkeeping were not created by the operator and are only deleted according to the unmanaged
+// policy.
+func (r *ChallengeDescriptionReconciler) deleteObsoleteChallenges(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdChallenges []ctfdapi.Challenge, challengeStatusBefore []v1alpha1.ChallengeDescriptionStatus, ctfd *v1alpha1.CTFd) error {
 	obsoleteChallenges := make([]ctfdapi.Challenge, len(ctfdChallenges))
 	copy(obsoleteChallenges, ctfdChallenges)
 	obsoleteChallenges = slices.DeleteFunc(obsoleteChallenges, func(ctfdChallenge ctfdapi.Challenge) bool {
-		return r.statusExistsForCTFdChallenge(ctfd.Status.ChallengeDescriptions, ctfdChallenge)
+		if r.statusExistsForCTFdChallenge(ctfd.Status.ChallengeDescriptions, ctfdChallenge) {
+			return true
+		}
+		if r.statusExistsForCTFdChallenge(challengeStatusBefore, ctfdChallenge) {
+			return false
+		}
+		return !r.deletesUnmanagedChallenge(ctfd, ctfdChallenge)
 	})
 	for _, ctfdChallenge := range obsoleteChallenges {
 		ctrl.LoggerFrom(ctx).Info(
@@ -253,8 +564,13 @@ func (r *ChallengeDescriptionReconciler) deleteObsoleteChallenges(ctx context.Co
 			"name", ctfdChallenge.Name,
 		)
 		if err := ctfdClient.DeleteChallenge(ctx, ctfdChallenge.Id); err != nil {
+			if ctfdapi.IsNotFound(err) {
+				// Recreated challenges delete their old challenge on their own.
+				continue
+			}
 			return err
 		}
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "ChallengeDeleted", "Deleted challenge %q with id %d", ctfdChallenge.Name, ctfdChallenge.Id)
 	}
 	return nil
 }
@@ -266,36 +582,137 @@ func (r *ChallengeDescriptionReconciler) resolveChallengeNamespace(ctfd *v1alpha
 	return *ctfd.Spec.ChallengeNamespace
 }
 
+// reconcilesChallenges returns true if the CTFd instance is configured to reconcile ChallengeDescriptions from at least
+// one source.
+func (r *ChallengeDescriptionReconciler) reconcilesChallenges(ctfd *v1alpha1.CTFd) bool {
+	return ctfd.Spec.ChallengeNamespace != nil ||
+		len(ctfd.Spec.ChallengeNamespaces) != 0 ||
+		ctfd.Spec.ChallengeNamespaceSelector != nil
+}
+
+// resolveChallengeNamespaces returns the sorted list of all namespaces to look for ChallengeDescriptions in.
+func (r *ChallengeDescriptionReconciler) resolveChallengeNamespaces(ctx context.Context, ctfd *v1alpha1.CTFd) ([]string, error) {
+	var result []string
+	if ctfd.Spec.ChallengeNamespace != nil {
+		result = append(result, r.resolveChallengeNamespace(ctfd))
+	}
+	result = append(result, ctfd.Spec.ChallengeNamespaces...)
+
+	if ctfd.Spec.ChallengeNamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(ctfd.Spec.ChallengeNamespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("parsing challenge namespace selector: %w", err)
+		}
+		var namespaceList corev1.NamespaceList
+		if err := r.GetClient().List(ctx, &namespaceList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, err
+		}
+		for _, namespace := range namespaceList.Items {
+			result = append(result, namespace.Name)
+		}
+	}
+
+	slices.Sort(result)
+	return slices.Compact(result), nil
+}
+
+func (r *ChallengeDescriptionReconciler) listK8sChallenges(ctx context.Context, namespaces []string) ([]v1alpha2.ChallengeDescription, error) {
+	var result []v1alpha2.ChallengeDescription
+	for _, namespace := range namespaces {
+		var challengeDescriptionList v1alpha2.ChallengeDescriptionList
+		if err := r.GetClient().List(ctx, &challengeDescriptionList, client.InNamespace(namespace)); err != nil {
+			return nil, err
+		}
+		result = append(result, challengeDescriptionList.Items...)
+	}
+	return result, nil
+}
+
+// resolveChallengeSelector returns the label selector for the ChallengeDescriptions to reconcile. Without a selector
+// in the spec, all ChallengeDescriptions are selected.
+func (r *ChallengeDescriptionReconciler) resolveChallengeSelector(ctfd *v1alpha1.CTFd) (labels.Selector, error) {
+	if ctfd.Spec.ChallengeSelector == nil {
+		return labels.Everything(), nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(ctfd.Spec.ChallengeSelector)
+	if err != nil {
+		return nil, fmt.Errorf("parsing challenge selector: %w", err)
+	}
+	return selector, nil
+}
+
 func (r *ChallengeDescriptionReconciler) SetCTFdEndpoint(endpoint CTFdEndpointStrategy) {
 	r.ctfdEndpoint = endpoint
 }
 
-func (r *ChallengeDescriptionReconciler) reconcileHints(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdChallenge ctfdapi.Challenge, ctfd *v1alpha1.CTFd, challengeStatus *v1alpha1.ChallengeDescriptionStatus, k8sHints []v1alpha2.ChallengeHint) error {
+func (r *ChallengeDescriptionReconciler) SetMinioEndpoint(endpoint MinioEndpointStrategy) {
+	r.minioEndpoint = endpoint
+}
+
+func (r *ChallengeDescriptionReconciler) reconcileHints(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdChallenge ctfdapi.Challenge, ctfd *v1alpha1.CTFd, challengeStatus *v1alpha1.ChallengeDescriptionStatus, k8sChallenge *v1alpha2.ChallengeDescription) error {
+	hintSettings, err := r.getHintSettings(k8sChallenge)
+	if err != nil {
+		return err
+	}
+	desiredHints := r.getDesiredHints(k8sChallenge.Spec.Hints, hintSettings)
+
 	ctfdHints, err := ctfdClient.ListHintsForChallenge(ctx, ctfdChallenge.Id)
 	if err != nil {
 		return err
 	}
 
-	r.cleanupHintStatus(challengeStatus, k8sHints, ctfdHints)
+	hintKeys := r.getHintKeys(k8sChallenge.Spec.Hints)
+	r.cleanupHintStatus(challengeStatus, hintKeys, ctfdHints)
 
-	if err := r.updateExistingHints(ctx, ctfdClient, ctfdChallenge, challengeStatus, ctfdHints, k8sHints); err != nil {
+	// Missing hints are created before existing hints are updated, as the requirements of a hint might reference a
+	// hint which does not exist yet.
+	if err := r.createMissingHints(ctx, ctfdClient, ctfdChallenge, ctfd, challengeStatus, desiredHints, hintKeys); err != nil {
 		return err
 	}
 
-	if err := r.createMissingHints(ctx, ctfdClient, ctfdChallenge, ctfd, challengeStatus, k8sHints); err != nil {
+	if err := r.updateExistingHints(ctx, ctfdClient, ctfdChallenge, ctfd, challengeStatus, desiredHints, hintSettings, hintKeys); err != nil {
 		return err
 	}
 
-	if err := r.deleteObsoleteHints(ctx, ctfdClient, ctfdChallenge, ctfdHints, challengeStatus); err != nil {
+	if err := r.deleteObsoleteHints(ctx, ctfdClient, ctfdChallenge, ctfd, ctfdHints, challengeStatus); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (r *ChallengeDescriptionReconciler) cleanupHintStatus(challengeStatus *v1alpha1.ChallengeDescriptionStatus, k8sHints []v1alpha2.ChallengeHint, ctfdHints []ctfdapi.Hint) {
+// getHintKeys returns the keys identifying the given hints. The key is derived from the content of the hint, so that
+// inserting, removing or reordering hints does not touch any other hint in CTFd. Hints with the same content are
+// distinguished by the number of their occurrence.
+func (r *ChallengeDescriptionReconciler) getHintKeys(k8sHints []v1alpha2.ChallengeHint) []string {
+	result := make([]string, 0, len(k8sHints))
+	occurrences := make(map[string]int)
+	for _, k8sHint := range k8sHints {
+		hash := sha256.Sum256([]byte(k8sHint.Description))
+		key := hex.EncodeToString(hash[:8])
+		if occurrence := occurrences[key]; occurrence != 0 {
+			result = append(result, key+"-"+strconv.Itoa(occurrence))
+		} else {
+			result = append(result, key)
+		}
+		occurrences[key]++
+	}
+	return result
+}
+
+func (r *ChallengeDescriptionReconciler) cleanupHintStatus(challengeStatus *v1alpha1.ChallengeDescriptionStatus, hintKeys []string, ctfdHints []ctfdapi.Hint) {
+	// Older versions identified hints by their position. We take the position one last time to find the key.
+	for i := range challengeStatus.Hints {
+		hintStatus := &challengeStatus.Hints[i]
+		if len(hintStatus.Key) != 0 || len(hintKeys) <= hintStatus.Index {
+			continue
+		}
+		hintStatus.Key = hintKeys[hintStatus.Index]
+		hintStatus.Index = 0
+	}
+
 	// Remove hints from the bookkeeping which can not be found in Kubernetes anymore.
 	challengeStatus.Hints = slices.DeleteFunc(challengeStatus.Hints, func(hintStatus v1alpha1.HintStatus) bool {
-		return len(k8sHints) <= hintStatus.Index
+		return !slices.Contains(hintKeys, hintStatus.Key)
 	})
 
 	// Remove hints from the bookkeeping which can not be found in CTFd anymore.
@@ -314,23 +731,24 @@ func (r *ChallengeDescriptionReconciler) ctfdHintExists(ctfdHints []ctfdapi.Hint
 	return r.getCTFdHintIndex(ctfdHints, hintStatus) != -1
 }
 
-func (r *ChallengeDescriptionReconciler) updateExistingHints(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdChallenge ctfdapi.Challenge, challengeStatus *v1alpha1.ChallengeDescriptionStatus, ctfdHints []ctfdapi.Hint, k8sHints []v1alpha2.ChallengeHint) error {
+func (r *ChallengeDescriptionReconciler) updateExistingHints(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdChallenge ctfdapi.Challenge, ctfd *v1alpha1.CTFd, challengeStatus *v1alpha1.ChallengeDescriptionStatus, desiredHints []ctfdapi.Hint, hintSettings []v1alpha1.ChallengeHintSettings, hintKeys []string) error {
 	for _, hintStatus := range challengeStatus.Hints {
-		ctfdHintIndex := r.getCTFdHintIndex(ctfdHints, hintStatus)
-		ctfdHint := ctfdHints[ctfdHintIndex]
-
 		// The CTFd list hints endpoint does not provide the hints themselves. Therefore, we need to update the hints
 		// with data from the get endpoint.
-		fullHint, err := ctfdClient.GetHint(ctx, ctfdHint.Id)
+		ctfdHint, err := ctfdClient.GetHint(ctx, hintStatus.Id)
+		if ctfdapi.IsNotFound(err) {
+			// The hint was deleted after we listed the hints. It is created again with the next reconcile, as the
+			// bookkeeping will not find the hint in CTFd anymore.
+			continue
+		}
 		if err != nil {
 			return err
 		}
-		ctfdHint = fullHint
 
-		k8sHint := k8sHints[hintStatus.Index]
-
-		if ctfdHint.Content == k8sHint.Description &&
-			ctfdHint.Cost == k8sHint.Cost {
+		desiredHintIdx := slices.Index(hintKeys, hintStatus.Key)
+		desiredHint := desiredHints[desiredHintIdx]
+		desiredHint.Requirements = r.getDesiredHintRequirements(*challengeStatus, hintSettings, hintKeys, desiredHintIdx)
+		if r.isSameHint(ctfdHint, desiredHint) {
 			continue
 		}
 		ctrl.LoggerFrom(ctx).Info(
@@ -339,45 +757,43 @@ func (r *ChallengeDescriptionReconciler) updateExistingHints(ctx context.Context
 			"challenge-id", ctfdChallenge.Id,
 			"challenge-name", ctfdChallenge.Name,
 		)
-		ctfdHint.Content = k8sHint.Description
-		ctfdHint.Cost = k8sHint.Cost
+		ctfdHint.Title = desiredHint.Title
+		ctfdHint.Type = desiredHint.Type
+		ctfdHint.Content = desiredHint.Content
+		ctfdHint.Cost = desiredHint.Cost
+		ctfdHint.Requirements = desiredHint.Requirements
 		if _, err := ctfdClient.UpdateHint(ctx, ctfdHint); err != nil {
 			return err
 		}
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "HintUpdated", "Updated hint with id %d of challenge %q", ctfdHint.Id, ctfdChallenge.Name)
 	}
 	return nil
 }
 
-func (r *ChallengeDescriptionReconciler) createMissingHints(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdChallenge ctfdapi.Challenge, ctfd *v1alpha1.CTFd, challengeStatus *v1alpha1.ChallengeDescriptionStatus, k8sHints []v1alpha2.ChallengeHint) error {
-	missingHintIdxs := make([]int, len(k8sHints))
-	for i := range missingHintIdxs {
-		missingHintIdxs[i] = i
-	}
-	missingHintIdxs = slices.DeleteFunc(missingHintIdxs, func(k8sHintIdx int) bool {
+func (r *ChallengeDescriptionReconciler) createMissingHints(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdChallenge ctfdapi.Challenge, ctfd *v1alpha1.CTFd, challengeStatus *v1alpha1.ChallengeDescriptionStatus, desiredHints []ctfdapi.Hint, hintKeys []string) error {
+	for desiredHintIdx, hintKey := range hintKeys {
 		index := slices.IndexFunc(challengeStatus.Hints, func(hintStatus v1alpha1.HintStatus) bool {
-			return hintStatus.Index == k8sHintIdx
+			return hintStatus.Key == hintKey
 		})
-		return index != -1
-	})
-	for _, missingHintIdx := range missingHintIdxs {
-		k8sHint := k8sHints[missingHintIdx]
+		if index != -1 {
+			continue
+		}
+		desiredHint := desiredHints[desiredHintIdx]
 		ctrl.LoggerFrom(ctx).Info(
 			"Creating hint",
-			"name", k8sHint.Description,
+			"name", desiredHint.Content,
 			"challenge-id", ctfdChallenge.Id,
 			"challenge-name", ctfdChallenge.Name,
 		)
-		ctfdHint, err := ctfdClient.CreateHint(ctx, ctfdapi.Hint{
-			ChallengeId: ctfdChallenge.Id,
-			Content:     k8sHint.Description,
-			Cost:        k8sHint.Cost,
-		})
+		desiredHint.ChallengeId = ctfdChallenge.Id
+		ctfdHint, err := ctfdClient.CreateHint(ctx, desiredHint)
 		if err != nil {
 			return err
 		}
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "HintCreated", "Created hint with id %d for challenge %q", ctfdHint.Id, ctfdChallenge.Name)
 		challengeStatus.Hints = append(challengeStatus.Hints, v1alpha1.HintStatus{
-			Id:    ctfdHint.Id,
-			Index: missingHintIdx,
+			Id:  ctfdHint.Id,
+			Key: hintKey,
 		})
 		if err := r.GetClient().Status().Update(ctx, ctfd); err != nil {
 			return err
@@ -386,7 +802,7 @@ func (r *ChallengeDescriptionReconciler) createMissingHints(ctx context.Context,
 	return nil
 }
 
-func (r *ChallengeDescriptionReconciler) deleteObsoleteHints(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdChallenge ctfdapi.Challenge, ctfdHints []ctfdapi.Hint, challengeStatus *v1alpha1.ChallengeDescriptionStatus) error {
+func (r *ChallengeDescriptionReconciler) deleteObsoleteHints(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdChallenge ctfdapi.Challenge, ctfd *v1alpha1.CTFd, ctfdHints []ctfdapi.Hint, challengeStatus *v1alpha1.ChallengeDescriptionStatus) error {
 	obsoleteHints := make([]ctfdapi.Hint, len(ctfdHints))
 	copy(obsoleteHints, ctfdHints)
 	obsoleteHints = slices.DeleteFunc(obsoleteHints, func(ctfdHint ctfdapi.Hint) bool {
@@ -402,61 +818,11 @@ func (r *ChallengeDescriptionReconciler) deleteObsoleteHints(ctx context.Context
 			"challenge-id", ctfdChallenge.Id,
 			"challenge-name", ctfdChallenge.Name,
 		)
-		if err := ctfdClient.DeleteHint(ctx, ctfdHint.Id); err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func (r *ChallengeDescriptionReconciler) reconcileFlag(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdChallenge ctfdapi.Challenge, k8sChallenge *v1alpha2.ChallengeDescription) error {
-	flags, err := ctfdClient.ListFlagsForChallenge(ctx, ctfdChallenge.Id)
-	if err != nil {
-		return err
-	}
-
-	// Create missing flag
-	if len(flags) < 1 {
-		ctrl.LoggerFrom(ctx).Info(
-			"Creating flag",
-			"challenge-id", ctfdChallenge.Id,
-			"challenge-name", ctfdChallenge.Name,
-		)
-		flag, err := ctfdClient.CreateFlag(ctx, ctfdapi.Flag{
-			ChallengeId: ctfdChallenge.Id,
-			Content:     k8sChallenge.Spec.Flag,
-		})
-		if err != nil {
-			return err
-		}
-		flags = append(flags, flag)
-	}
-
-	// Delete obsolete flags
-	for flagIdx := 1; flagIdx < len(flags); flagIdx++ {
-		ctrl.LoggerFrom(ctx).Info(
-			"Deleting flag",
-			"id", flags[flagIdx].Id,
-			"challenge-id", ctfdChallenge.Id,
-			"challenge-name", ctfdChallenge.Name,
-		)
-		if err := ctfdClient.DeleteFlag(ctx, flags[flagIdx].Id); err != nil {
-			return err
-		}
-	}
-
-	// Update existing flag
-	if flags[0].Content != k8sChallenge.Spec.Flag {
-		flags[0].Content = k8sChallenge.Spec.Flag
-		ctrl.LoggerFrom(ctx).Info(
-			"Updating flag",
-			"id", flags[0].Id,
-			"challenge-id", ctfdChallenge.Id,
-			"challenge-name", ctfdChallenge.Name,
-		)
-		if _, err := ctfdClient.UpdateFlag(ctx, flags[0]); err != nil {
+		// The hint might have been deleted by someone else in the meantime, which is fine for us.
+		if err := ctfdapi.IgnoreNotFound(ctfdClient.DeleteHint(ctx, ctfdHint.Id)); err != nil {
 			return err
 		}
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "HintDeleted", "Deleted hint with id %d of challenge %q", ctfdHint.Id, ctfdChallenge.Name)
 	}
 	return nil
 }
@@ -1,8 +1,12 @@
 package ctfd_test
 
 import (
+	"time"
+
 	v1alpha2 "github.com/backbone81/ctf-challenge-operator/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -24,7 +28,7 @@ var _ = Describe("ChallengeDescriptionReconciler", func() {
 	)
 
 	BeforeEach(func() {
-		reconciler = ctfd.NewReconciler(k8sClient, ctfd.WithChallengeDescriptionReconciler(WithCTFdTestEndpoint(endpointUrl)))
+		reconciler = ctfd.NewReconciler(k8sClient, recorder, ctfd.WithChallengeDescriptionReconciler(WithCTFdTestEndpoint(endpointUrl)))
 		var err error
 		ctfdClient, err = ctfdapi.NewClient(endpointUrl, accessToken)
 		Expect(err).ToNot(HaveOccurred())
@@ -71,6 +75,7 @@ var _ = Describe("ChallengeDescriptionReconciler", func() {
 		Expect(err).ToNot(HaveOccurred())
 		challengesAfter := len(challenges)
 		Expect(challengesAfter).To(Equal(challengesBefore + 1))
+		Expect(RecordedEvents()).To(ContainElement(HavePrefix("Normal ChallengeCreated")))
 	})
 
 	It("should successfully create the hint", func(ctx SpecContext) {
@@ -109,6 +114,175 @@ var _ = Describe("ChallengeDescriptionReconciler", func() {
 		Expect(err).ToNot(HaveOccurred())
 		hintsAfter := len(hints)
 		Expect(hintsAfter).To(Equal(hintsBefore + 1))
+		Expect(RecordedEvents()).To(ContainElement(HavePrefix("Normal HintCreated")))
+	})
+
+	It("should sync hint titles and requirements from the annotation", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				ChallengeNamespace: ptr.To(corev1.NamespaceDefault),
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		challengeDescription, err := CreateChallengeDescription(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		challengeDescription.Spec.Hints = []v1alpha2.ChallengeHint{
+			{
+				Description: "This is a free hint",
+			},
+			{
+				Description: "This is a paid hint",
+				Cost:        10,
+			},
+		}
+		challengeDescription.Annotations = map[string]string{
+			v1alpha1.ChallengeHintsAnnotation: `[{"description": "This is a paid hint", "title": "Exploit", "requiresPrevious": true}]`,
+		}
+		Expect(k8sClient.Update(ctx, challengeDescription)).To(Succeed())
+
+		By("run the reconciler")
+		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		By("verify all postconditions")
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		Expect(instance.Status.ChallengeDescriptions[0].Hints).To(HaveLen(2))
+		freeHint, err := ctfdClient.GetHint(ctx, instance.Status.ChallengeDescriptions[0].Hints[0].Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(freeHint.Title).To(Equal("Free hint"))
+		paidHint, err := ctfdClient.GetHint(ctx, instance.Status.ChallengeDescriptions[0].Hints[1].Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(paidHint.Title).To(Equal("Exploit"))
+		Expect(paidHint.Requirements).To(HaveValue(HaveField("Prerequisites", ConsistOf(freeHint.Id))))
+	})
+
+	It("should keep existing hints when a hint is inserted at the top", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				ChallengeNamespace: ptr.To(corev1.NamespaceDefault),
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		challengeDescription, err := CreateChallengeDescription(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		challengeDescription.Spec.Hints = append(challengeDescription.Spec.Hints, v1alpha2.ChallengeHint{
+			Description: "This is the original hint",
+		})
+		Expect(k8sClient.Update(ctx, challengeDescription)).To(Succeed())
+
+		Expect(reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))).To(BeZero())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		Expect(instance.Status.ChallengeDescriptions[0].Hints).To(HaveLen(1))
+		originalHintId := instance.Status.ChallengeDescriptions[0].Hints[0].Id
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(challengeDescription), challengeDescription)).To(Succeed())
+		challengeDescription.Spec.Hints = append([]v1alpha2.ChallengeHint{
+			{
+				Description: "This is the inserted hint",
+			},
+		}, challengeDescription.Spec.Hints...)
+		Expect(k8sClient.Update(ctx, challengeDescription)).To(Succeed())
+		RecordedEvents()
+
+		By("run the reconciler")
+		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		By("verify all postconditions")
+		originalHint, err := ctfdClient.GetHint(ctx, originalHintId)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(originalHint.Content).To(Equal("This is the original hint"))
+		hints, err := ctfdClient.ListHintsForChallenge(ctx, instance.Status.ChallengeDescriptions[0].Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hints).To(HaveLen(2))
+		events := RecordedEvents()
+		Expect(events).To(ContainElement(HavePrefix("Normal HintCreated")))
+		Expect(events).ToNot(ContainElement(HavePrefix("Normal HintUpdated")))
+		Expect(events).ToNot(ContainElement(HavePrefix("Normal HintDeleted")))
+	})
+
+	It("should keep the hint settings with their hints when a hint is inserted at the top", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				ChallengeNamespace: ptr.To(corev1.NamespaceDefault),
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		challengeDescription, err := CreateChallengeDescription(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		challengeDescription.Spec.Hints = []v1alpha2.ChallengeHint{
+			{
+				Description: "This is a free hint",
+			},
+			{
+				Description: "This is a paid hint",
+				Cost:        10,
+			},
+		}
+		challengeDescription.Annotations = map[string]string{
+			v1alpha1.ChallengeHintsAnnotation: `[{"description": "This is a paid hint", "title": "Exploit", "requiresPrevious": true}]`,
+		}
+		Expect(k8sClient.Update(ctx, challengeDescription)).To(Succeed())
+		Expect(reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))).To(BeZero())
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(challengeDescription), challengeDescription)).To(Succeed())
+		challengeDescription.Spec.Hints = append([]v1alpha2.ChallengeHint{
+			{
+				Description: "This is the inserted hint",
+				Cost:        5,
+			},
+		}, challengeDescription.Spec.Hints...)
+		Expect(k8sClient.Update(ctx, challengeDescription)).To(Succeed())
+
+		By("run the reconciler")
+		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		By("verify all postconditions")
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		Expect(instance.Status.ChallengeDescriptions[0].Hints).To(HaveLen(3))
+		hintsByContent := make(map[string]ctfdapi.Hint)
+		for _, hintStatus := range instance.Status.ChallengeDescriptions[0].Hints {
+			hint, err := ctfdClient.GetHint(ctx, hintStatus.Id)
+			Expect(err).ToNot(HaveOccurred())
+			hintsByContent[hint.Content] = hint
+		}
+		insertedHint := hintsByContent["This is the inserted hint"]
+		Expect(insertedHint.Title).To(BeEmpty())
+		Expect(insertedHint.Requirements).To(Or(BeNil(), HaveField("Prerequisites", BeEmpty())))
+		freeHint := hintsByContent["This is a free hint"]
+		Expect(freeHint.Title).To(Equal("Free hint"))
+		Expect(freeHint.Requirements).To(Or(BeNil(), HaveField("Prerequisites", BeEmpty())))
+		paidHint := hintsByContent["This is a paid hint"]
+		Expect(paidHint.Title).To(Equal("Exploit"))
+		Expect(paidHint.Requirements).To(HaveValue(HaveField("Prerequisites", ConsistOf(freeHint.Id))))
 	})
 
 	It("should successfully create the flag", func(ctx SpecContext) {
@@ -142,9 +316,10 @@ var _ = Describe("ChallengeDescriptionReconciler", func() {
 		Expect(err).ToNot(HaveOccurred())
 		flagsAfter := len(flags)
 		Expect(flagsAfter).To(Equal(flagsBefore + 1))
+		Expect(RecordedEvents()).To(ContainElement(HavePrefix("Normal FlagCreated")))
 	})
 
-	It("should delete manual created challenges", func(ctx SpecContext) {
+	It("should successfully create additional flags from the annotation", func(ctx SpecContext) {
 		By("prepare test with all preconditions")
 		instance := AddDefaults(v1alpha1.CTFd{
 			ObjectMeta: metav1.ObjectMeta{
@@ -156,16 +331,15 @@ var _ = Describe("ChallengeDescriptionReconciler", func() {
 			},
 		})
 		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
-		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
 		instance.Status.Ready = true
 		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
-		Expect(ctfdClient.CreateChallenge(ctx, ctfdapi.Challenge{
-			Name:        "test",
-			Description: "test",
-		})).Error().ToNot(HaveOccurred())
-		challenges, err := ctfdClient.ListChallenges(ctx)
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		challengeDescription, err := CreateChallengeDescription(ctx)
 		Expect(err).ToNot(HaveOccurred())
-		challengesBefore := len(challenges)
+		challengeDescription.Annotations = map[string]string{
+			v1alpha1.ChallengeFlagsAnnotation: `[{"content": "ctf{variant}", "caseInsensitive": true}, {"content": "CTF\\{[a-z]+\\}", "type": "regex"}]`,
+		}
+		Expect(k8sClient.Update(ctx, challengeDescription)).To(Succeed())
 
 		By("run the reconciler")
 		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
@@ -173,13 +347,17 @@ var _ = Describe("ChallengeDescriptionReconciler", func() {
 		Expect(result).To(BeZero())
 
 		By("verify all postconditions")
-		challenges, err = ctfdClient.ListChallenges(ctx)
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		flags, err := ctfdClient.ListFlagsForChallenge(ctx, instance.Status.ChallengeDescriptions[0].Id)
 		Expect(err).ToNot(HaveOccurred())
-		challengesAfter := len(challenges)
-		Expect(challengesAfter).To(Equal(challengesBefore - 1))
+		Expect(flags).To(ConsistOf(
+			SatisfyAll(HaveField("Type", v1alpha1.FlagTypeStatic), HaveField("Content", "CTF{test}"), HaveField("Data", "")),
+			SatisfyAll(HaveField("Type", v1alpha1.FlagTypeStatic), HaveField("Content", "ctf{variant}"), HaveField("Data", "case_insensitive")),
+			SatisfyAll(HaveField("Type", v1alpha1.FlagTypeRegex), HaveField("Content", `CTF\{[a-z]+\}`), HaveField("Data", "")),
+		))
 	})
 
-	It("should delete manual created hints", func(ctx SpecContext) {
+	It("should recreate the challenge when switching to dynamic scoring", func(ctx SpecContext) {
 		By("prepare test with all preconditions")
 		instance := AddDefaults(v1alpha1.CTFd{
 			ObjectMeta: metav1.ObjectMeta{
@@ -191,44 +369,95 @@ var _ = Describe("ChallengeDescriptionReconciler", func() {
 			},
 		})
 		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
-		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
 		instance.Status.Ready = true
 		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
 		challengeDescription, err := CreateChallengeDescription(ctx)
 		Expect(err).ToNot(HaveOccurred())
-		challengeDescription.Spec.Hints = append(challengeDescription.Spec.Hints, v1alpha2.ChallengeHint{
-			Description: "This is a test hint",
-		})
+
+		Expect(reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))).To(BeZero())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		oldChallengeId := instance.Status.ChallengeDescriptions[0].Id
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(challengeDescription), challengeDescription)).To(Succeed())
+		challengeDescription.Spec.Value = 500
+		challengeDescription.Annotations = map[string]string{
+			v1alpha1.ChallengeDynamicScoringAnnotation: `{"decay": 10, "minimum": 100}`,
+		}
 		Expect(k8sClient.Update(ctx, challengeDescription)).To(Succeed())
 
+		By("run the reconciler")
 		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
 		Expect(err).ToNot(HaveOccurred())
 		Expect(result).To(BeZero())
 
+		By("verify all postconditions")
 		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		Expect(instance.Status.ChallengeDescriptions).To(HaveLen(1))
+		newChallengeId := instance.Status.ChallengeDescriptions[0].Id
+		Expect(newChallengeId).ToNot(Equal(oldChallengeId))
 
-		Expect(ctfdClient.CreateHint(ctx, ctfdapi.Hint{
-			Content:     "Additional hint",
-			ChallengeId: instance.Status.ChallengeDescriptions[0].Id,
-		})).Error().ToNot(HaveOccurred())
+		challenges, err := ctfdClient.ListChallenges(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(challenges).To(ConsistOf(HaveField("Id", newChallengeId)))
+		challenge, err := ctfdClient.GetChallenge(ctx, newChallengeId)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(challenge.Type).To(Equal(v1alpha1.ChallengeTypeDynamic))
+		Expect(challenge.Initial).To(Equal(500))
+		Expect(challenge.Decay).To(Equal(10))
+		Expect(challenge.Minimum).To(Equal(100))
+		flags, err := ctfdClient.ListFlagsForChallenge(ctx, newChallengeId)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(flags).To(HaveLen(1))
+		Expect(RecordedEvents()).To(ContainElement(HavePrefix("Normal ChallengeRecreated")))
+	})
 
-		hints, err := ctfdClient.ListHints(ctx)
+	It("should delete the old challenge when a step after recreating the challenge fails", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				ChallengeNamespace: ptr.To(corev1.NamespaceDefault),
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		challengeDescription, err := CreateChallengeDescription(ctx)
 		Expect(err).ToNot(HaveOccurred())
-		hintsBefore := len(hints)
+
+		Expect(reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))).To(BeZero())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		oldChallengeId := instance.Status.ChallengeDescriptions[0].Id
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(challengeDescription), challengeDescription)).To(Succeed())
+		challengeDescription.Annotations = map[string]string{
+			v1alpha1.ChallengeDynamicScoringAnnotation: `{"decay": 10, "minimum": 100}`,
+			v1alpha1.ChallengeFilesAnnotation:          `[{"configMap": {"name": "does-not-exist", "key": "capture.pcap"}}]`,
+		}
+		Expect(k8sClient.Update(ctx, challengeDescription)).To(Succeed())
 
 		By("run the reconciler")
-		result, err = reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
-		Expect(err).ToNot(HaveOccurred())
-		Expect(result).To(BeZero())
+		Expect(reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))).Error().To(HaveOccurred())
 
 		By("verify all postconditions")
-		hints, err = ctfdClient.ListHints(ctx)
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		Expect(instance.Status.ChallengeDescriptions).To(HaveLen(1))
+		newChallengeId := instance.Status.ChallengeDescriptions[0].Id
+		Expect(newChallengeId).ToNot(Equal(oldChallengeId))
+
+		challenges, err := ctfdClient.ListChallenges(ctx)
 		Expect(err).ToNot(HaveOccurred())
-		hintsAfter := len(hints)
-		Expect(hintsAfter).To(Equal(hintsBefore - 1))
+		Expect(challenges).To(ConsistOf(HaveField("Id", newChallengeId)))
+		_, err = ctfdClient.GetChallenge(ctx, oldChallengeId)
+		Expect(ctfdapi.IsNotFound(err)).To(BeTrue())
 	})
 
-	It("should delete manual created flags", func(ctx SpecContext) {
+	It("should sync the challenge settings from the annotations", func(ctx SpecContext) {
 		By("prepare test with all preconditions")
 		instance := AddDefaults(v1alpha1.CTFd{
 			ObjectMeta: metav1.ObjectMeta{
@@ -240,35 +469,1036 @@ var _ = Describe("ChallengeDescriptionReconciler", func() {
 			},
 		})
 		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
-		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
 		instance.Status.Ready = true
 		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
-		Expect(CreateChallengeDescription(ctx)).Error().ToNot(HaveOccurred())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		challengeDescription, err := CreateChallengeDescription(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		challengeDescription.Annotations = map[string]string{
+			v1alpha1.ChallengeStateAnnotation:          v1alpha1.ChallengeStateHidden,
+			v1alpha1.ChallengeMaxAttemptsAnnotation:    "3",
+			v1alpha1.ChallengeAttributionAnnotation:    "The Test Team",
+			v1alpha1.ChallengeConnectionInfoAnnotation: "nc localhost 1337",
+		}
+		Expect(k8sClient.Update(ctx, challengeDescription)).To(Succeed())
+
+		Expect(reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))).To(BeZero())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		challenge, err := ctfdClient.GetChallenge(ctx, instance.Status.ChallengeDescriptions[0].Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(challenge.State).To(Equal(v1alpha1.ChallengeStateHidden))
+		Expect(challenge.MaxAttempts).To(HaveValue(Equal(3)))
+		Expect(challenge.Attribution).To(HaveValue(Equal("The Test Team")))
+		Expect(challenge.ConnectionInfo).To(HaveValue(Equal("nc localhost 1337")))
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(challengeDescription), challengeDescription)).To(Succeed())
+		challengeDescription.Annotations[v1alpha1.ChallengeStateAnnotation] = v1alpha1.ChallengeStateVisible
+		challengeDescription.Annotations[v1alpha1.ChallengeMaxAttemptsAnnotation] = "0"
+		Expect(k8sClient.Update(ctx, challengeDescription)).To(Succeed())
 
+		By("run the reconciler")
 		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
 		Expect(err).ToNot(HaveOccurred())
 		Expect(result).To(BeZero())
 
-		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		By("verify all postconditions")
+		challenge, err = ctfdClient.GetChallenge(ctx, instance.Status.ChallengeDescriptions[0].Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(challenge.State).To(Equal(v1alpha1.ChallengeStateVisible))
+		Expect(challenge.MaxAttempts).To(HaveValue(Equal(0)))
+		Expect(RecordedEvents()).To(ContainElement(HavePrefix("Normal ChallengeUpdated")))
+	})
 
-		Expect(ctfdClient.CreateFlag(ctx, ctfdapi.Flag{
-			ChallengeId: instance.Status.ChallengeDescriptions[0].Id,
-			Content:     "CTF{additional_flag}",
-		})).Error().ToNot(HaveOccurred())
+	It("should render the connection info from the endpoint of the challenge instance", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				ChallengeNamespace: ptr.To(corev1.NamespaceDefault),
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		challengeDescription, err := CreateChallengeDescription(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		challengeDescription.Annotations = map[string]string{
+			v1alpha1.ChallengeConnectionInfoAnnotation:         "Not running yet",
+			v1alpha1.ChallengeConnectionInfoTemplateAnnotation: "nc {{.Host}} {{.Port}}",
+		}
+		Expect(k8sClient.Update(ctx, challengeDescription)).To(Succeed())
 
-		flags, err := ctfdClient.ListFlags(ctx)
+		Expect(reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))).To(BeZero())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		challenge, err := ctfdClient.GetChallenge(ctx, instance.Status.ChallengeDescriptions[0].Id)
 		Expect(err).ToNot(HaveOccurred())
-		flagsBefore := len(flags)
+		Expect(challenge.ConnectionInfo).To(HaveValue(Equal("Not running yet")))
+
+		challengeInstance := v1alpha2.ChallengeInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha2.ChallengeInstanceSpec{
+				ChallengeDescriptionName: challengeDescription.Name,
+			},
+		}
+		Expect(k8sClient.Create(ctx, &challengeInstance)).To(Succeed())
+		DeferCleanup(func(ctx SpecContext) {
+			Expect(k8sClient.Delete(ctx, &challengeInstance)).To(Succeed())
+		})
+		Expect(k8sClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: challengeInstance.Name,
+			},
+		})).To(Succeed())
+		ingress := networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: challengeInstance.Name,
+			},
+			Spec: networkingv1.IngressSpec{
+				Rules: []networkingv1.IngressRule{
+					{
+						Host: "challenge.ctf.internal",
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, &ingress)).To(Succeed())
 
 		By("run the reconciler")
-		result, err = reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
 		Expect(err).ToNot(HaveOccurred())
 		Expect(result).To(BeZero())
 
 		By("verify all postconditions")
-		flags, err = ctfdClient.ListFlags(ctx)
+		challenge, err = ctfdClient.GetChallenge(ctx, instance.Status.ChallengeDescriptions[0].Id)
 		Expect(err).ToNot(HaveOccurred())
-		flagsAfter := len(flags)
-		Expect(flagsAfter).To(Equal(flagsBefore - 1))
+		Expect(challenge.ConnectionInfo).To(HaveValue(Equal("nc challenge.ctf.internal 80")))
+
+		ingress.Spec.TLS = []networkingv1.IngressTLS{
+			{
+				Hosts: []string{"challenge.ctf.internal"},
+			},
+		}
+		Expect(k8sClient.Update(ctx, &ingress)).To(Succeed())
+		Expect(reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))).To(BeZero())
+		challenge, err = ctfdClient.GetChallenge(ctx, instance.Status.ChallengeDescriptions[0].Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(challenge.ConnectionInfo).To(HaveValue(Equal("nc challenge.ctf.internal 443")))
+		Expect(RecordedEvents()).ToNot(ContainElement(HavePrefix("Warning DriftCorrected")))
+
+		subReconciler := ctfd.NewChallengeDescriptionReconciler(k8sClient, recorder, WithCTFdTestEndpoint(endpointUrl))
+		Expect(subReconciler.MapInstanceEndpointToCTFds(ctx, &ingress)).To(ContainElement(testutils.RequestFromObject(&instance)))
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(challengeDescription), challengeDescription)).To(Succeed())
+		delete(challengeDescription.Annotations, v1alpha1.ChallengeConnectionInfoTemplateAnnotation)
+		Expect(k8sClient.Update(ctx, challengeDescription)).To(Succeed())
+		Expect(subReconciler.MapInstanceEndpointToCTFds(ctx, &ingress)).To(BeEmpty())
+	})
+
+	It("should keep challenges hidden until their release time", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				ChallengeNamespace: ptr.To(corev1.NamespaceDefault),
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		releasedChallengeDescription, err := CreateChallengeDescription(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		releasedChallengeDescription.Annotations = map[string]string{
+			v1alpha1.ChallengeReleaseTimeAnnotation: time.Now().Add(-time.Hour).Format(time.RFC3339),
+		}
+		Expect(k8sClient.Update(ctx, releasedChallengeDescription)).To(Succeed())
+		unreleasedChallengeDescription, err := CreateChallengeDescription(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		unreleasedChallengeDescription.Annotations = map[string]string{
+			v1alpha1.ChallengeReleaseTimeAnnotation: time.Now().Add(time.Hour).Format(time.RFC3339),
+		}
+		Expect(k8sClient.Update(ctx, unreleasedChallengeDescription)).To(Succeed())
+
+		By("run the reconciler")
+		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+
+		By("verify all postconditions")
+		Expect(result.RequeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		releasedIndex := instance.Status.GetChallengeDescriptionIndex(*releasedChallengeDescription)
+		Expect(releasedIndex).ToNot(Equal(-1))
+		releasedChallenge, err := ctfdClient.GetChallenge(ctx, instance.Status.ChallengeDescriptions[releasedIndex].Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(releasedChallenge.State).To(Equal(v1alpha1.ChallengeStateVisible))
+		unreleasedIndex := instance.Status.GetChallengeDescriptionIndex(*unreleasedChallengeDescription)
+		Expect(unreleasedIndex).ToNot(Equal(-1))
+		unreleasedChallenge, err := ctfdClient.GetChallenge(ctx, instance.Status.ChallengeDescriptions[unreleasedIndex].Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(unreleasedChallenge.State).To(Equal(v1alpha1.ChallengeStateHidden))
+	})
+
+	It("should resolve prerequisites into challenge requirements", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				ChallengeNamespace: ptr.To(corev1.NamespaceDefault),
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		prerequisiteChallengeDescription, err := CreateChallengeDescription(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		challengeDescription, err := CreateChallengeDescription(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		challengeDescription.Annotations = map[string]string{
+			v1alpha1.ChallengePrerequisitesAnnotation: prerequisiteChallengeDescription.Name,
+		}
+		Expect(k8sClient.Update(ctx, challengeDescription)).To(Succeed())
+
+		By("run the reconciler")
+		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		By("verify all postconditions")
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		prerequisiteIndex := instance.Status.GetChallengeDescriptionIndex(*prerequisiteChallengeDescription)
+		Expect(prerequisiteIndex).ToNot(Equal(-1))
+		challengeIndex := instance.Status.GetChallengeDescriptionIndex(*challengeDescription)
+		Expect(challengeIndex).ToNot(Equal(-1))
+		requirements, err := ctfdClient.GetChallengeRequirements(ctx, instance.Status.ChallengeDescriptions[challengeIndex].Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requirements.Prerequisites).To(ConsistOf(instance.Status.ChallengeDescriptions[prerequisiteIndex].Id))
+		Expect(RecordedEvents()).To(ContainElement(HavePrefix("Normal RequirementsUpdated")))
+	})
+
+	It("should fail for prerequisites which are not reconciled", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				ChallengeNamespace: ptr.To(corev1.NamespaceDefault),
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		challengeDescription, err := CreateChallengeDescription(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		challengeDescription.Annotations = map[string]string{
+			v1alpha1.ChallengePrerequisitesAnnotation: "does-not-exist",
+		}
+		Expect(k8sClient.Update(ctx, challengeDescription)).To(Succeed())
+
+		By("run the reconciler")
+		_, err = reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+
+		By("verify all postconditions")
+		Expect(err).To(HaveOccurred())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		Expect(instance.Status.ChallengeDescriptions).To(HaveLen(1))
+	})
+
+	It("should sync tags and topics from the annotations", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				ChallengeNamespace: ptr.To(corev1.NamespaceDefault),
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		challengeDescription, err := CreateChallengeDescription(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		challengeDescription.Annotations = map[string]string{
+			v1alpha1.ChallengeTagsAnnotation:   "easy, web",
+			v1alpha1.ChallengeTopicsAnnotation: "SQL Injection",
+		}
+		Expect(k8sClient.Update(ctx, challengeDescription)).To(Succeed())
+
+		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		challengeId := instance.Status.ChallengeDescriptions[0].Id
+		tags, err := ctfdClient.ListTagsForChallenge(ctx, challengeId)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tags).To(ConsistOf(HaveField("Value", "easy"), HaveField("Value", "web")))
+		topics, err := ctfdClient.ListTopicsForChallenge(ctx, challengeId)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(topics).To(ConsistOf(HaveField("Value", "SQL Injection")))
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(challengeDescription), challengeDescription)).To(Succeed())
+		challengeDescription.Annotations[v1alpha1.ChallengeTagsAnnotation] = "hard, web"
+		challengeDescription.Annotations[v1alpha1.ChallengeTopicsAnnotation] = ""
+		Expect(k8sClient.Update(ctx, challengeDescription)).To(Succeed())
+
+		By("run the reconciler")
+		result, err = reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		By("verify all postconditions")
+		tags, err = ctfdClient.ListTagsForChallenge(ctx, challengeId)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tags).To(ConsistOf(HaveField("Value", "hard"), HaveField("Value", "web")))
+		topics, err = ctfdClient.ListTopicsForChallenge(ctx, challengeId)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(topics).To(BeEmpty())
+	})
+
+	It("should upload files and replace them when their content changes", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				ChallengeNamespace: ptr.To(corev1.NamespaceDefault),
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		configMap := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			BinaryData: map[string][]byte{
+				"capture.pcap": []byte("first version"),
+			},
+		}
+		Expect(k8sClient.Create(ctx, &configMap)).To(Succeed())
+		DeferCleanup(func(ctx SpecContext) {
+			Expect(k8sClient.Delete(ctx, &configMap)).To(Succeed())
+		})
+		challengeDescription, err := CreateChallengeDescription(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		challengeDescription.Annotations = map[string]string{
+			v1alpha1.ChallengeFilesAnnotation: `[{"configMap": {"name": "` + configMap.Name + `", "key": "capture.pcap"}}]`,
+		}
+		Expect(k8sClient.Update(ctx, challengeDescription)).To(Succeed())
+
+		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		challengeStatus := instance.Status.ChallengeDescriptions[0]
+		Expect(challengeStatus.Files).To(ConsistOf(HaveField("Name", "capture.pcap")))
+		firstFile := challengeStatus.Files[0]
+		files, err := ctfdClient.ListFilesForChallenge(ctx, challengeStatus.Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(files).To(ConsistOf(HaveField("Id", firstFile.Id)))
+
+		By("run the reconciler without changes")
+		result, err = reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		Expect(instance.Status.ChallengeDescriptions[0].Files).To(ConsistOf(firstFile))
+
+		configMap.BinaryData["capture.pcap"] = []byte("second version")
+		Expect(k8sClient.Update(ctx, &configMap)).To(Succeed())
+
+		By("run the reconciler")
+		result, err = reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		By("verify all postconditions")
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		challengeStatus = instance.Status.ChallengeDescriptions[0]
+		Expect(challengeStatus.Files).To(ConsistOf(SatisfyAll(
+			HaveField("Name", "capture.pcap"),
+			HaveField("Id", Not(Equal(firstFile.Id))),
+			HaveField("Hash", Not(Equal(firstFile.Hash))),
+		)))
+		files, err = ctfdClient.ListFilesForChallenge(ctx, challengeStatus.Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(files).To(ConsistOf(HaveField("Id", challengeStatus.Files[0].Id)))
+	})
+
+	It("should delete manual created challenges", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				ChallengeNamespace: ptr.To(corev1.NamespaceDefault),
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(ctfdClient.CreateChallenge(ctx, ctfdapi.Challenge{
+			Name:        "test",
+			Description: "test",
+		})).Error().ToNot(HaveOccurred())
+		challenges, err := ctfdClient.ListChallenges(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		challengesBefore := len(challenges)
+
+		By("run the reconciler")
+		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		By("verify all postconditions")
+		challenges, err = ctfdClient.ListChallenges(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		challengesAfter := len(challenges)
+		Expect(challengesAfter).To(Equal(challengesBefore - 1))
+		Expect(RecordedEvents()).To(ContainElement(HavePrefix("Normal ChallengeDeleted")))
+	})
+
+	It("should keep manual created challenges with the keep policy", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				ChallengeNamespace: ptr.To(corev1.NamespaceDefault),
+				ChallengeSync: v1alpha1.ChallengeSyncSpec{
+					UnmanagedPolicy: v1alpha1.UnmanagedChallengePolicyKeep,
+				},
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		manualChallenge, err := ctfdClient.CreateChallenge(ctx, ctfdapi.Challenge{
+			Name:        "test",
+			Description: "test",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		challengeDescription, err := CreateChallengeDescription(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))).To(BeZero())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		managedChallengeId := instance.Status.ChallengeDescriptions[0].Id
+		Expect(k8sClient.Delete(ctx, challengeDescription)).To(Succeed())
+
+		By("run the reconciler")
+		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		By("verify all postconditions")
+		challenges, err := ctfdClient.ListChallenges(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(challenges).To(ContainElement(HaveField("Id", manualChallenge.Id)))
+		Expect(challenges).ToNot(ContainElement(HaveField("Id", managedChallengeId)))
+	})
+
+	It("should only delete manual created challenges in the managed categories", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				ChallengeNamespace: ptr.To(corev1.NamespaceDefault),
+				ChallengeSync: v1alpha1.ChallengeSyncSpec{
+					Categories: []string{"managed"},
+				},
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		managedChallenge, err := ctfdClient.CreateChallenge(ctx, ctfdapi.Challenge{
+			Name:        "test",
+			Description: "test",
+			Category:    "managed",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		unmanagedChallenge, err := ctfdClient.CreateChallenge(ctx, ctfdapi.Challenge{
+			Name:        "test",
+			Description: "test",
+			Category:    "other",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		By("run the reconciler")
+		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		By("verify all postconditions")
+		challenges, err := ctfdClient.ListChallenges(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(challenges).ToNot(ContainElement(HaveField("Id", managedChallenge.Id)))
+		Expect(challenges).To(ContainElement(HaveField("Id", unmanagedChallenge.Id)))
+	})
+
+	It("should adopt manual created challenges with the adopt policy", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				ChallengeNamespace: ptr.To(corev1.NamespaceDefault),
+				ChallengeSync: v1alpha1.ChallengeSyncSpec{
+					UnmanagedPolicy: v1alpha1.UnmanagedChallengePolicyAdopt,
+				},
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		adoptableChallenge, err := ctfdClient.CreateChallenge(ctx, ctfdapi.Challenge{
+			Name:        "Test Challenge",
+			Description: "outdated description",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		otherChallenge, err := ctfdClient.CreateChallenge(ctx, ctfdapi.Challenge{
+			Name:        "test",
+			Description: "test",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(CreateChallengeDescription(ctx)).Error().ToNot(HaveOccurred())
+
+		By("run the reconciler")
+		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		By("verify all postconditions")
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		Expect(instance.Status.ChallengeDescriptions).To(HaveLen(1))
+		Expect(instance.Status.ChallengeDescriptions[0].Id).To(Equal(adoptableChallenge.Id))
+		challenge, err := ctfdClient.GetChallenge(ctx, adoptableChallenge.Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(challenge.Description).To(Equal("This is a test challenge"))
+		challenges, err := ctfdClient.ListChallenges(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(challenges).To(ContainElement(HaveField("Id", otherChallenge.Id)))
+		events := RecordedEvents()
+		Expect(events).To(ContainElement(HavePrefix("Normal ChallengeAdopted")))
+		Expect(events).ToNot(ContainElement(HavePrefix("Normal ChallengeCreated")))
+	})
+
+	It("should delete manual created hints", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				ChallengeNamespace: ptr.To(corev1.NamespaceDefault),
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		challengeDescription, err := CreateChallengeDescription(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		challengeDescription.Spec.Hints = append(challengeDescription.Spec.Hints, v1alpha2.ChallengeHint{
+			Description: "This is a test hint",
+		})
+		Expect(k8sClient.Update(ctx, challengeDescription)).To(Succeed())
+
+		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+
+		Expect(ctfdClient.CreateHint(ctx, ctfdapi.Hint{
+			Content:     "Additional hint",
+			ChallengeId: instance.Status.ChallengeDescriptions[0].Id,
+		})).Error().ToNot(HaveOccurred())
+
+		hints, err := ctfdClient.ListHints(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		hintsBefore := len(hints)
+
+		By("run the reconciler")
+		result, err = reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		By("verify all postconditions")
+		hints, err = ctfdClient.ListHints(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		hintsAfter := len(hints)
+		Expect(hintsAfter).To(Equal(hintsBefore - 1))
+	})
+
+	It("should delete manual created flags", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				ChallengeNamespace: ptr.To(corev1.NamespaceDefault),
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateChallengeDescription(ctx)).Error().ToNot(HaveOccurred())
+
+		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+
+		Expect(ctfdClient.CreateFlag(ctx, ctfdapi.Flag{
+			ChallengeId: instance.Status.ChallengeDescriptions[0].Id,
+			Content:     "CTF{additional_flag}",
+		})).Error().ToNot(HaveOccurred())
+
+		flags, err := ctfdClient.ListFlags(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		flagsBefore := len(flags)
+
+		By("run the reconciler")
+		result, err = reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		By("verify all postconditions")
+		flags, err = ctfdClient.ListFlags(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		flagsAfter := len(flags)
+		Expect(flagsAfter).To(Equal(flagsBefore - 1))
+	})
+
+	It("should delete the challenge when the ChallengeDescription is deleted", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				ChallengeNamespace: ptr.To(corev1.NamespaceDefault),
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		challengeDescription, err := CreateChallengeDescription(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		challenges, err := ctfdClient.ListChallenges(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		challengesBefore := len(challenges)
+
+		Expect(reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))).To(BeZero())
+		Expect(k8sClient.Delete(ctx, challengeDescription)).To(Succeed())
+
+		By("run the reconciler")
+		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		By("verify all postconditions")
+		challenges, err = ctfdClient.ListChallenges(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(challenges).To(HaveLen(challengesBefore))
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(challengeDescription), challengeDescription)).ToNot(Succeed())
+	})
+
+	It("should delete all challenges when the CTFd is deleted", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				ChallengeNamespace: ptr.To(corev1.NamespaceDefault),
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		challengeDescription, err := CreateChallengeDescription(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		challenges, err := ctfdClient.ListChallenges(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		challengesBefore := len(challenges)
+
+		Expect(reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))).To(BeZero())
+		Expect(k8sClient.Delete(ctx, &instance)).To(Succeed())
+
+		By("run the reconciler")
+		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		By("verify all postconditions")
+		challenges, err = ctfdClient.ListChallenges(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(challenges).To(HaveLen(challengesBefore))
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).ToNot(Succeed())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(challengeDescription), challengeDescription)).To(Succeed())
+	})
+
+	It("should requeue after the challenge sync interval", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				ChallengeNamespace: ptr.To(corev1.NamespaceDefault),
+				ChallengeSync: v1alpha1.ChallengeSyncSpec{
+					Interval: &metav1.Duration{Duration: 5 * time.Minute},
+				},
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		Expect(CreateChallengeDescription(ctx)).Error().ToNot(HaveOccurred())
+
+		By("run the reconciler")
+		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+
+		By("verify all postconditions")
+		Expect(result.RequeueAfter).To(Equal(5 * time.Minute))
+	})
+
+	It("should correct drift with the enforce policy", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				ChallengeNamespace: ptr.To(corev1.NamespaceDefault),
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		challengeDescription, err := CreateChallengeDescription(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))).To(BeZero())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+
+		challenge, err := ctfdClient.GetChallenge(ctx, instance.Status.ChallengeDescriptions[0].Id)
+		Expect(err).ToNot(HaveOccurred())
+		challenge.Value = challengeDescription.Spec.Value + 100
+		Expect(ctfdClient.UpdateChallenge(ctx, challenge)).Error().ToNot(HaveOccurred())
+
+		By("run the reconciler")
+		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		By("verify all postconditions")
+		challenge, err = ctfdClient.GetChallenge(ctx, instance.Status.ChallengeDescriptions[0].Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(challenge.Value).To(Equal(challengeDescription.Spec.Value))
+		Expect(RecordedEvents()).To(ContainElement(HavePrefix("Warning DriftCorrected")))
+	})
+
+	It("should only report drift with the report-only policy", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				ChallengeNamespace: ptr.To(corev1.NamespaceDefault),
+				ChallengeSync: v1alpha1.ChallengeSyncSpec{
+					Policy: v1alpha1.ChallengeSyncPolicyReportOnly,
+				},
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		challengeDescription, err := CreateChallengeDescription(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))).To(BeZero())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+
+		challenge, err := ctfdClient.GetChallenge(ctx, instance.Status.ChallengeDescriptions[0].Id)
+		Expect(err).ToNot(HaveOccurred())
+		challenge.Value = challengeDescription.Spec.Value + 100
+		Expect(ctfdClient.UpdateChallenge(ctx, challenge)).Error().ToNot(HaveOccurred())
+
+		By("run the reconciler")
+		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		By("verify all postconditions")
+		challenge, err = ctfdClient.GetChallenge(ctx, challenge.Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(challenge.Value).To(Equal(challengeDescription.Spec.Value + 100))
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		Expect(instance.Status.ChallengeDescriptions[0].Drift).To(ConsistOf("value"))
+		Expect(meta.FindStatusCondition(instance.Status.Conditions, v1alpha1.ConditionTypeChallengesSynced)).To(
+			HaveField("Reason", "DriftDetected"),
+		)
+		Expect(RecordedEvents()).To(ContainElement(HavePrefix("Warning DriftDetected")))
+	})
+
+	It("should only reconcile ChallengeDescriptions matching the challenge selector", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				ChallengeNamespace: ptr.To(corev1.NamespaceDefault),
+				ChallengeSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"event": "practice",
+					},
+				},
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		challengeDescription, err := CreateChallengeDescription(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		By("run the reconciler without a matching label")
+		Expect(reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))).To(BeZero())
+		challenges, err := ctfdClient.ListChallenges(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(challenges).To(BeEmpty())
+
+		By("run the reconciler with a matching label")
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(challengeDescription), challengeDescription)).To(Succeed())
+		challengeDescription.Labels = map[string]string{
+			"event": "practice",
+		}
+		Expect(k8sClient.Update(ctx, challengeDescription)).To(Succeed())
+		Expect(reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))).To(BeZero())
+		challenges, err = ctfdClient.ListChallenges(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(challenges).To(HaveLen(1))
+
+		By("run the reconciler after the label stopped matching")
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(challengeDescription), challengeDescription)).To(Succeed())
+		challengeDescription.Labels["event"] = "competition"
+		Expect(k8sClient.Update(ctx, challengeDescription)).To(Succeed())
+		Expect(reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))).To(BeZero())
+
+		By("verify all postconditions")
+		challenges, err = ctfdClient.ListChallenges(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(challenges).To(BeEmpty())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(challengeDescription), challengeDescription)).To(Succeed())
+		Expect(RecordedEvents()).To(ContainElement(HavePrefix("Normal ChallengeDeleted")))
+	})
+
+	It("should map a ChallengeDescription to all CTFd instances watching its namespace", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		sameNamespaceInstance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				ChallengeNamespace: ptr.To(""),
+			},
+		})
+		Expect(k8sClient.Create(ctx, &sameNamespaceInstance)).To(Succeed())
+		explicitNamespaceInstance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				ChallengeNamespace: ptr.To(corev1.NamespaceDefault),
+			},
+		})
+		Expect(k8sClient.Create(ctx, &explicitNamespaceInstance)).To(Succeed())
+		otherNamespaceInstance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				ChallengeNamespace: ptr.To("other"),
+			},
+		})
+		Expect(k8sClient.Create(ctx, &otherNamespaceInstance)).To(Succeed())
+		noNamespaceInstance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+		})
+		Expect(k8sClient.Create(ctx, &noNamespaceInstance)).To(Succeed())
+		challengeDescription, err := CreateChallengeDescription(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		By("run the mapping")
+		subReconciler := ctfd.NewChallengeDescriptionReconciler(k8sClient, recorder, WithCTFdTestEndpoint(endpointUrl))
+		requests := subReconciler.MapChallengeDescriptionToCTFds(ctx, challengeDescription)
+
+		By("verify all postconditions")
+		Expect(requests).To(ConsistOf(
+			testutils.RequestFromObject(&sameNamespaceInstance),
+			testutils.RequestFromObject(&explicitNamespaceInstance),
+		))
+	})
+
+	It("should map a ChallengeDescription to all CTFd instances watching its namespace through a list or selector", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		namespaceListInstance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				ChallengeNamespaces: []string{"other", corev1.NamespaceDefault},
+			},
+		})
+		Expect(k8sClient.Create(ctx, &namespaceListInstance)).To(Succeed())
+		namespaceSelectorInstance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				ChallengeNamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						corev1.LabelMetadataName: corev1.NamespaceDefault,
+					},
+				},
+			},
+		})
+		Expect(k8sClient.Create(ctx, &namespaceSelectorInstance)).To(Succeed())
+		otherNamespaceSelectorInstance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				ChallengeNamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						corev1.LabelMetadataName: "other",
+					},
+				},
+			},
+		})
+		Expect(k8sClient.Create(ctx, &otherNamespaceSelectorInstance)).To(Succeed())
+		challengeDescription, err := CreateChallengeDescription(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		By("run the mapping")
+		subReconciler := ctfd.NewChallengeDescriptionReconciler(k8sClient, recorder, WithCTFdTestEndpoint(endpointUrl))
+		requests := subReconciler.MapChallengeDescriptionToCTFds(ctx, challengeDescription)
+
+		By("verify all postconditions")
+		Expect(requests).To(ConsistOf(
+			testutils.RequestFromObject(&namespaceListInstance),
+			testutils.RequestFromObject(&namespaceSelectorInstance),
+		))
+	})
+
+	It("should map a ConfigMap to the CTFd instances only when a ChallengeDescription references it", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				ChallengeNamespace: ptr.To(corev1.NamespaceDefault),
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		referencedConfigMap := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "referenced",
+				Namespace: corev1.NamespaceDefault,
+			},
+		}
+		unreferencedConfigMap := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "unreferenced",
+				Namespace: corev1.NamespaceDefault,
+			},
+		}
+		challengeDescription, err := CreateChallengeDescription(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		challengeDescription.Annotations = map[string]string{
+			v1alpha1.ChallengeFilesAnnotation: `[{"configMap": {"name": "referenced", "key": "capture.pcap"}}]`,
+		}
+		Expect(k8sClient.Update(ctx, challengeDescription)).To(Succeed())
+
+		By("run the mapping")
+		subReconciler := ctfd.NewChallengeDescriptionReconciler(k8sClient, recorder, WithCTFdTestEndpoint(endpointUrl))
+
+		By("verify all postconditions")
+		Expect(subReconciler.MapFileSourceToCTFds(ctx, &referencedConfigMap)).To(ConsistOf(testutils.RequestFromObject(&instance)))
+		Expect(subReconciler.MapFileSourceToCTFds(ctx, &unreferencedConfigMap)).To(BeEmpty())
 	})
 })
@@ -0,0 +1,134 @@
+package ctfd
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	v1alpha2 "github.com/backbone81/ctf-challenge-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/backbone81/ctf-ui-operator/api/v1alpha1"
+	"github.com/backbone81/ctf-ui-operator/internal/ctfdapi"
+)
+
+// detectDrift returns the parts of the challenge in CTFd which differ from the ChallengeDescription. The given CTFd
+// challenge needs to be retrieved through the get endpoint, as the list endpoint does not provide all fields.
+func (r *ChallengeDescriptionReconciler) detectDrift(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdChallenge ctfdapi.Challenge, challengeStatus v1alpha1.ChallengeDescriptionStatus, k8sChallenge *v1alpha2.ChallengeDescription) ([]string, error) {
+	desiredChallenge, err := r.getDesiredChallenge(k8sChallenge)
+	if err != nil {
+		return nil, err
+	}
+	result := r.getChangedChallengeFields(ctfdChallenge, desiredChallenge)
+
+	hintsDrifted, err := r.hintsDrifted(ctx, ctfdClient, ctfdChallenge, challengeStatus, k8sChallenge)
+	if err != nil {
+		return nil, err
+	}
+	if hintsDrifted {
+		result = append(result, "hints")
+	}
+
+	desiredFlags, err := r.getDesiredFlags(k8sChallenge)
+	if err != nil {
+		return nil, err
+	}
+	ctfdFlags, err := ctfdClient.ListFlagsForChallenge(ctx, ctfdChallenge.Id)
+	if err != nil {
+		return nil, err
+	}
+	if r.flagsDrifted(ctfdFlags, desiredFlags) {
+		result = append(result, "flags")
+	}
+
+	tagsDrifted, err := r.tagsDrifted(ctx, ctfdClient, ctfdChallenge, k8sChallenge)
+	if err != nil {
+		return nil, err
+	}
+	if tagsDrifted {
+		result = append(result, "tags")
+	}
+
+	topicsDrifted, err := r.topicsDrifted(ctx, ctfdClient, ctfdChallenge, k8sChallenge)
+	if err != nil {
+		return nil, err
+	}
+	if topicsDrifted {
+		result = append(result, "topics")
+	}
+
+	filesDrifted, err := r.filesDrifted(ctx, ctfdClient, ctfdChallenge, challengeStatus, k8sChallenge)
+	if err != nil {
+		return nil, err
+	}
+	if filesDrifted {
+		result = append(result, "files")
+	}
+	return result, nil
+}
+
+func (r *ChallengeDescriptionReconciler) hintsDrifted(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdChallenge ctfdapi.Challenge, challengeStatus v1alpha1.ChallengeDescriptionStatus, k8sChallenge *v1alpha2.ChallengeDescription) (bool, error) {
+	k8sHints := k8sChallenge.Spec.Hints
+	ctfdHints, err := ctfdClient.ListHintsForChallenge(ctx, ctfdChallenge.Id)
+	if err != nil {
+		return false, err
+	}
+	if len(ctfdHints) != len(k8sHints) || len(challengeStatus.Hints) != len(k8sHints) {
+		return true, nil
+	}
+
+	hintSettings, err := r.getHintSettings(k8sChallenge)
+	if err != nil {
+		return false, err
+	}
+	desiredHints := r.getDesiredHints(k8sHints, hintSettings)
+	hintKeys := r.getHintKeys(k8sHints)
+	for _, hintStatus := range challengeStatus.Hints {
+		desiredHintIdx := slices.Index(hintKeys, hintStatus.Key)
+		if !r.ctfdHintExists(ctfdHints, hintStatus) || desiredHintIdx == -1 {
+			return true, nil
+		}
+
+		// The CTFd list hints endpoint does not provide the hints themselves. Therefore, we need to compare with the
+		// data from the get endpoint.
+		ctfdHint, err := ctfdClient.GetHint(ctx, hintStatus.Id)
+		if ctfdapi.IsNotFound(err) {
+			// The hint was deleted after we listed the hints.
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		desiredHint := desiredHints[desiredHintIdx]
+		desiredHint.Requirements = r.getDesiredHintRequirements(challengeStatus, hintSettings, hintKeys, desiredHintIdx)
+		if !r.isSameHint(ctfdHint, desiredHint) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// recordDrift stores the drift in the status of the challenge. An event is only emitted when the drift changed, to not
+// spam an event on every resync.
+func (r *ChallengeDescriptionReconciler) recordDrift(ctfd *v1alpha1.CTFd, challengeStatus *v1alpha1.ChallengeDescriptionStatus, ctfdChallenge ctfdapi.Challenge, drift []string) {
+	if slices.Equal(challengeStatus.Drift, drift) {
+		return
+	}
+	challengeStatus.Drift = drift
+
+	if ctfd.Spec.ChallengeSync.GetPolicy() == v1alpha1.ChallengeSyncPolicyDeferToCTFd {
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "DriftAccepted", "Accepted changes to %s of challenge %q with id %d", strings.Join(drift, ", "), ctfdChallenge.Name, ctfdChallenge.Id)
+		return
+	}
+	r.GetRecorder().Eventf(ctfd, corev1.EventTypeWarning, "DriftDetected", "Detected drift in %s of challenge %q with id %d", strings.Join(drift, ", "), ctfdChallenge.Name, ctfdChallenge.Id)
+}
+
+func (r *ChallengeDescriptionReconciler) countDriftedChallenges(ctfd *v1alpha1.CTFd) int {
+	result := 0
+	for _, challengeStatus := range ctfd.Status.ChallengeDescriptions {
+		if len(challengeStatus.Drift) != 0 {
+			result++
+		}
+	}
+	return result
+}
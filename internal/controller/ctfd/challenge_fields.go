@@ -0,0 +1,200 @@
+package ctfd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	v1alpha2 "github.com/backbone81/ctf-challenge-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/backbone81/ctf-ui-operator/api/v1alpha1"
+	"github.com/backbone81/ctf-ui-operator/internal/ctfdapi"
+)
+
+// getDesiredChallenge returns the challenge as it should be stored in CTFd according to the ChallengeDescription and
+// its annotations.
+func (r *ChallengeDescriptionReconciler) getDesiredChallenge(k8sChallenge *v1alpha2.ChallengeDescription) (ctfdapi.Challenge, error) {
+	result := ctfdapi.Challenge{
+		Name:        k8sChallenge.Spec.Title,
+		Description: k8sChallenge.Spec.Description,
+		Value:       k8sChallenge.Spec.Value,
+		Category:    k8sChallenge.Spec.Category,
+		Type:        v1alpha1.ChallengeTypeStandard,
+	}
+
+	var dynamicScoring v1alpha1.ChallengeDynamicScoring
+	ok, err := getJSONAnnotation(k8sChallenge, v1alpha1.ChallengeDynamicScoringAnnotation, &dynamicScoring)
+	if err != nil {
+		return ctfdapi.Challenge{}, err
+	}
+	if ok {
+		result.Type = v1alpha1.ChallengeTypeDynamic
+		result.Initial = k8sChallenge.Spec.Value
+		result.Decay = dynamicScoring.Decay
+		result.Minimum = dynamicScoring.Minimum
+		result.Function = dynamicScoring.Function
+		switch result.Function {
+		case "":
+			result.Function = v1alpha1.DecayFunctionLogarithmic
+		case v1alpha1.DecayFunctionLogarithmic, v1alpha1.DecayFunctionLinear:
+		default:
+			return ctfdapi.Challenge{}, fmt.Errorf("parsing annotation %s: unknown decay function %q", v1alpha1.ChallengeDynamicScoringAnnotation, dynamicScoring.Function)
+		}
+	}
+
+	if state, ok := k8sChallenge.Annotations[v1alpha1.ChallengeStateAnnotation]; ok {
+		if state != v1alpha1.ChallengeStateVisible && state != v1alpha1.ChallengeStateHidden {
+			return ctfdapi.Challenge{}, fmt.Errorf("parsing annotation %s: unknown state %q", v1alpha1.ChallengeStateAnnotation, state)
+		}
+		result.State = state
+	}
+	released, err := isReleased(k8sChallenge, time.Now())
+	if err != nil {
+		return ctfdapi.Challenge{}, err
+	}
+	if !released {
+		result.State = v1alpha1.ChallengeStateHidden
+	} else if _, ok := k8sChallenge.Annotations[v1alpha1.ChallengeReleaseTimeAnnotation]; ok && result.State == "" {
+		result.State = v1alpha1.ChallengeStateVisible
+	}
+
+	if maxAttempts, ok := k8sChallenge.Annotations[v1alpha1.ChallengeMaxAttemptsAnnotation]; ok {
+		value, err := strconv.Atoi(maxAttempts)
+		if err != nil || value < 0 {
+			return ctfdapi.Challenge{}, fmt.Errorf("parsing annotation %s: invalid number of attempts %q", v1alpha1.ChallengeMaxAttemptsAnnotation, maxAttempts)
+		}
+		result.MaxAttempts = ptr.To(value)
+	}
+	if attribution, ok := k8sChallenge.Annotations[v1alpha1.ChallengeAttributionAnnotation]; ok {
+		result.Attribution = ptr.To(attribution)
+	}
+	if connectionInfo, ok := k8sChallenge.Annotations[v1alpha1.ChallengeConnectionInfoAnnotation]; ok {
+		result.ConnectionInfo = ptr.To(connectionInfo)
+	}
+	return result, nil
+}
+
+// getChangedChallengeFields returns the names of the fields which differ between the challenge in CTFd and the desired
+// challenge. The value of dynamic challenges is calculated by CTFd, so we compare the scoring configuration instead.
+// Optional fields which are not set in the desired challenge are not managed by the operator and therefore ignored.
+func (r *ChallengeDescriptionReconciler) getChangedChallengeFields(ctfdChallenge ctfdapi.Challenge, desiredChallenge ctfdapi.Challenge) []string {
+	var result []string
+	if ctfdChallenge.Name != desiredChallenge.Name {
+		result = append(result, "title")
+	}
+	if ctfdChallenge.Description != desiredChallenge.Description {
+		result = append(result, "description")
+	}
+	if ctfdChallenge.Category != desiredChallenge.Category {
+		result = append(result, "category")
+	}
+	if ctfdChallenge.Type != desiredChallenge.Type {
+		result = append(result, "type")
+	}
+	if desiredChallenge.Type == v1alpha1.ChallengeTypeDynamic {
+		if ctfdChallenge.Initial != desiredChallenge.Initial ||
+			ctfdChallenge.Decay != desiredChallenge.Decay ||
+			ctfdChallenge.Minimum != desiredChallenge.Minimum ||
+			ctfdChallenge.Function != desiredChallenge.Function {
+			result = append(result, "scoring")
+		}
+	} else if ctfdChallenge.Value != desiredChallenge.Value {
+		result = append(result, "value")
+	}
+	if desiredChallenge.State != "" && ctfdChallenge.State != desiredChallenge.State {
+		result = append(result, "state")
+	}
+	if desiredChallenge.MaxAttempts != nil && ptr.Deref(ctfdChallenge.MaxAttempts, 0) != *desiredChallenge.MaxAttempts {
+		result = append(result, "maxAttempts")
+	}
+	if desiredChallenge.Attribution != nil && ptr.Deref(ctfdChallenge.Attribution, "") != *desiredChallenge.Attribution {
+		result = append(result, "attribution")
+	}
+	if desiredChallenge.ConnectionInfo != nil && ptr.Deref(ctfdChallenge.ConnectionInfo, "") != *desiredChallenge.ConnectionInfo {
+		result = append(result, "connectionInfo")
+	}
+	return result
+}
+
+// applyDesiredChallenge copies the fields managed by the operator from the desired challenge to the challenge in CTFd.
+func (r *ChallengeDescriptionReconciler) applyDesiredChallenge(ctfdChallenge *ctfdapi.Challenge, desiredChallenge ctfdapi.Challenge) {
+	ctfdChallenge.Name = desiredChallenge.Name
+	ctfdChallenge.Description = desiredChallenge.Description
+	ctfdChallenge.Category = desiredChallenge.Category
+	if desiredChallenge.Type == v1alpha1.ChallengeTypeDynamic {
+		ctfdChallenge.Initial = desiredChallenge.Initial
+		ctfdChallenge.Decay = desiredChallenge.Decay
+		ctfdChallenge.Minimum = desiredChallenge.Minimum
+		ctfdChallenge.Function = desiredChallenge.Function
+	} else {
+		ctfdChallenge.Value = desiredChallenge.Value
+	}
+	if desiredChallenge.State != "" {
+		ctfdChallenge.State = desiredChallenge.State
+	}
+	if desiredChallenge.MaxAttempts != nil {
+		ctfdChallenge.MaxAttempts = desiredChallenge.MaxAttempts
+	}
+	if desiredChallenge.Attribution != nil {
+		ctfdChallenge.Attribution = desiredChallenge.Attribution
+	}
+	if desiredChallenge.ConnectionInfo != nil {
+		ctfdChallenge.ConnectionInfo = desiredChallenge.ConnectionInfo
+	}
+}
+
+// recreateChallenge creates a new challenge with the desired type and moves the bookkeeping over to the new challenge.
+// CTFd does not support changing the type of existing challenges. The old challenge is deleted as soon as the new one
+// is persisted in the bookkeeping, as a later failure would otherwise leave it behind as an unmanaged challenge.
+func (r *ChallengeDescriptionReconciler) recreateChallenge(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdChallenge ctfdapi.Challenge, desiredChallenge ctfdapi.Challenge, ctfd *v1alpha1.CTFd, challengeStatus *v1alpha1.ChallengeDescriptionStatus) (ctfdapi.Challenge, error) {
+	ctrl.LoggerFrom(ctx).Info(
+		"Recreating challenge",
+		"id", ctfdChallenge.Id,
+		"name", ctfdChallenge.Name,
+		"old-type", ctfdChallenge.Type,
+		"new-type", desiredChallenge.Type,
+	)
+
+	// Settings which are not managed by the operator are taken over from the old challenge.
+	if desiredChallenge.State == "" {
+		desiredChallenge.State = ctfdChallenge.State
+	}
+	if desiredChallenge.MaxAttempts == nil {
+		desiredChallenge.MaxAttempts = ctfdChallenge.MaxAttempts
+	}
+	if desiredChallenge.Attribution == nil {
+		desiredChallenge.Attribution = ctfdChallenge.Attribution
+	}
+	if desiredChallenge.ConnectionInfo == nil {
+		desiredChallenge.ConnectionInfo = ctfdChallenge.ConnectionInfo
+	}
+
+	newChallenge, err := ctfdClient.CreateChallenge(ctx, desiredChallenge)
+	if err != nil {
+		return ctfdapi.Challenge{}, err
+	}
+	r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "ChallengeRecreated", "Recreated challenge %q with id %d as id %d to change its type from %s to %s", newChallenge.Name, ctfdChallenge.Id, newChallenge.Id, ctfdChallenge.Type, newChallenge.Type)
+
+	// The hints and files belong to the old challenge and are removed together with it.
+	challengeStatus.Id = newChallenge.Id
+	challengeStatus.Hints = nil
+	challengeStatus.Files = nil
+	if err := r.GetClient().Status().Update(ctx, ctfd); err != nil {
+		return ctfdapi.Challenge{}, err
+	}
+
+	ctrl.LoggerFrom(ctx).Info(
+		"Deleting challenge",
+		"id", ctfdChallenge.Id,
+		"name", ctfdChallenge.Name,
+	)
+	if err := ctfdapi.IgnoreNotFound(ctfdClient.DeleteChallenge(ctx, ctfdChallenge.Id)); err != nil {
+		return ctfdapi.Challenge{}, err
+	}
+	r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "ChallengeDeleted", "Deleted challenge %q with id %d", ctfdChallenge.Name, ctfdChallenge.Id)
+	return newChallenge, nil
+}
@@ -0,0 +1,352 @@
+package ctfd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+
+	v1alpha2 "github.com/backbone81/ctf-challenge-operator/api/v1alpha1"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/backbone81/ctf-ui-operator/api/v1alpha1"
+	"github.com/backbone81/ctf-ui-operator/internal/ctfdapi"
+)
+
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
+
+// desiredFile is a file from the files annotation together with its content. The content is nil for Minio objects
+// which did not change since they were uploaded.
+type desiredFile struct {
+	Name    string
+	Hash    string
+	ETag    string
+	Content []byte
+}
+
+// reconcileFiles makes sure that the challenge in CTFd has exactly the files from the files annotation. Files are
+// identified by their name and only uploaded again when the hash of their content changed. Without the annotation,
+// files are only managed if the operator uploaded files for the challenge before.
+func (r *ChallengeDescriptionReconciler) reconcileFiles(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdChallenge ctfdapi.Challenge, ctfd *v1alpha1.CTFd, challengeStatus *v1alpha1.ChallengeDescriptionStatus, k8sChallenge *v1alpha2.ChallengeDescription) error {
+	if _, ok := k8sChallenge.Annotations[v1alpha1.ChallengeFilesAnnotation]; !ok && len(challengeStatus.Files) == 0 {
+		return nil
+	}
+
+	// The bookkeeping needs to be cleaned up before the desired files are determined. Otherwise, the download of a
+	// Minio object would be skipped for a file which needs to be uploaded again.
+	ctfdFiles, err := ctfdClient.ListFilesForChallenge(ctx, ctfdChallenge.Id)
+	if err != nil {
+		return err
+	}
+	r.cleanupFileStatus(challengeStatus, ctfdFiles)
+
+	desiredFiles, err := r.getDesiredFiles(ctx, ctfd, k8sChallenge, challengeStatus.Files)
+	if err != nil {
+		return err
+	}
+
+	// Upload missing and changed files
+	for _, desiredFile := range desiredFiles {
+		fileStatusIdx := slices.IndexFunc(challengeStatus.Files, func(fileStatus v1alpha1.FileStatus) bool {
+			return fileStatus.Name == desiredFile.Name
+		})
+		if fileStatusIdx != -1 && challengeStatus.Files[fileStatusIdx].Hash == desiredFile.Hash {
+			challengeStatus.Files[fileStatusIdx].ETag = desiredFile.ETag
+			continue
+		}
+		if desiredFile.Content == nil {
+			// This must never happen, as it would replace the file with an empty one under the hash of the old content.
+			return fmt.Errorf("content of file %q was not downloaded", desiredFile.Name)
+		}
+
+		ctrl.LoggerFrom(ctx).Info(
+			"Uploading file",
+			"name", desiredFile.Name,
+			"challenge-id", ctfdChallenge.Id,
+			"challenge-name", ctfdChallenge.Name,
+		)
+		file, err := ctfdClient.UploadFile(ctx, ctfdChallenge.Id, desiredFile.Name, bytes.NewReader(desiredFile.Content))
+		if err != nil {
+			return err
+		}
+		fileStatus := v1alpha1.FileStatus{
+			Id:   file.Id,
+			Name: desiredFile.Name,
+			Hash: desiredFile.Hash,
+			ETag: desiredFile.ETag,
+		}
+		if fileStatusIdx == -1 {
+			challengeStatus.Files = append(challengeStatus.Files, fileStatus)
+			r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "FileUploaded", "Uploaded file %q with id %d for challenge %q", desiredFile.Name, file.Id, ctfdChallenge.Name)
+			continue
+		}
+
+		// The old file is only removed after the new file was uploaded, so that players always have a file to
+		// download.
+		oldFileId := challengeStatus.Files[fileStatusIdx].Id
+		challengeStatus.Files[fileStatusIdx] = fileStatus
+		if err := ctfdapi.IgnoreNotFound(ctfdClient.DeleteFile(ctx, oldFileId)); err != nil {
+			return err
+		}
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "FileReplaced", "Replaced file %q with id %d of challenge %q with id %d", desiredFile.Name, oldFileId, ctfdChallenge.Name, file.Id)
+	}
+
+	// Delete obsolete files
+	challengeStatus.Files = slices.DeleteFunc(challengeStatus.Files, func(fileStatus v1alpha1.FileStatus) bool {
+		return !slices.ContainsFunc(desiredFiles, func(desiredFile desiredFile) bool {
+			return desiredFile.Name == fileStatus.Name
+		})
+	})
+	for _, ctfdFile := range ctfdFiles {
+		if slices.ContainsFunc(challengeStatus.Files, func(fileStatus v1alpha1.FileStatus) bool {
+			return fileStatus.Id == ctfdFile.Id
+		}) {
+			continue
+		}
+		ctrl.LoggerFrom(ctx).Info(
+			"Deleting file",
+			"id", ctfdFile.Id,
+			"challenge-id", ctfdChallenge.Id,
+			"challenge-name", ctfdChallenge.Name,
+		)
+		if err := ctfdapi.IgnoreNotFound(ctfdClient.DeleteFile(ctx, ctfdFile.Id)); err != nil {
+			return err
+		}
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "FileDeleted", "Deleted file %q with id %d of challenge %q", ctfdFile.Name(), ctfdFile.Id, ctfdChallenge.Name)
+	}
+	return nil
+}
+
+// cleanupFileStatus removes the bookkeeping of files which do not exist in CTFd anymore. Those files are uploaded
+// again.
+func (r *ChallengeDescriptionReconciler) cleanupFileStatus(challengeStatus *v1alpha1.ChallengeDescriptionStatus, ctfdFiles []ctfdapi.File) {
+	challengeStatus.Files = slices.DeleteFunc(challengeStatus.Files, func(fileStatus v1alpha1.FileStatus) bool {
+		return !slices.ContainsFunc(ctfdFiles, func(ctfdFile ctfdapi.File) bool {
+			return ctfdFile.Id == fileStatus.Id
+		})
+	})
+}
+
+// getDesiredFiles returns the files from the files annotation together with their content. The bookkeeping of the
+// uploaded files allows skipping the download of Minio objects which did not change.
+func (r *ChallengeDescriptionReconciler) getDesiredFiles(ctx context.Context, ctfd *v1alpha1.CTFd, k8sChallenge *v1alpha2.ChallengeDescription, fileStatuses []v1alpha1.FileStatus) ([]desiredFile, error) {
+	var k8sFiles []v1alpha1.ChallengeFile
+	if _, err := getJSONAnnotation(k8sChallenge, v1alpha1.ChallengeFilesAnnotation, &k8sFiles); err != nil {
+		return nil, err
+	}
+
+	var result []desiredFile
+	for _, k8sFile := range k8sFiles {
+		file, err := r.getDesiredFile(ctx, ctfd, k8sChallenge, k8sFile, fileStatuses)
+		if err != nil {
+			return nil, err
+		}
+		if slices.ContainsFunc(result, func(desiredFile desiredFile) bool {
+			return desiredFile.Name == file.Name
+		}) {
+			return nil, fmt.Errorf("parsing annotation %s: duplicate file name %q", v1alpha1.ChallengeFilesAnnotation, file.Name)
+		}
+		result = append(result, file)
+	}
+	return result, nil
+}
+
+// getDesiredFile returns the given file together with its content from its source.
+func (r *ChallengeDescriptionReconciler) getDesiredFile(ctx context.Context, ctfd *v1alpha1.CTFd, k8sChallenge *v1alpha2.ChallengeDescription, k8sFile v1alpha1.ChallengeFile, fileStatuses []v1alpha1.FileStatus) (desiredFile, error) {
+	var (
+		content []byte
+		err     error
+	)
+	name := r.getFileName(k8sFile)
+	switch {
+	case k8sFile.ConfigMap != nil && k8sFile.Secret == nil && k8sFile.Minio == nil:
+		content, err = r.getConfigMapContent(ctx, k8sChallenge.Namespace, *k8sFile.ConfigMap)
+	case k8sFile.ConfigMap == nil && k8sFile.Secret != nil && k8sFile.Minio == nil:
+		content, err = r.getSecretContent(ctx, k8sChallenge.Namespace, *k8sFile.Secret)
+	case k8sFile.ConfigMap == nil && k8sFile.Secret == nil && k8sFile.Minio != nil:
+		return r.getMinioFile(ctx, ctfd, name, *k8sFile.Minio, fileStatuses)
+	default:
+		return desiredFile{}, fmt.Errorf("parsing annotation %s: exactly one of configMap, secret and minio is required", v1alpha1.ChallengeFilesAnnotation)
+	}
+	if err != nil {
+		return desiredFile{}, err
+	}
+	hash := sha256.Sum256(content)
+	return desiredFile{
+		Name:    name,
+		Hash:    hex.EncodeToString(hash[:]),
+		Content: content,
+	}, nil
+}
+
+// getFileName returns the name of the given file as shown to players.
+func (r *ChallengeDescriptionReconciler) getFileName(k8sFile v1alpha1.ChallengeFile) string {
+	switch {
+	case len(k8sFile.Name) != 0:
+		return k8sFile.Name
+	case k8sFile.ConfigMap != nil:
+		return k8sFile.ConfigMap.Key
+	case k8sFile.Secret != nil:
+		return k8sFile.Secret.Key
+	case k8sFile.Minio != nil:
+		return path.Base(k8sFile.Minio.Object)
+	}
+	return ""
+}
+
+func (r *ChallengeDescriptionReconciler) getConfigMapContent(ctx context.Context, namespace string, selector v1alpha1.ChallengeFileKeySelector) ([]byte, error) {
+	var configMap corev1.ConfigMap
+	if err := r.GetClient().Get(ctx, client.ObjectKey{
+		Name:      selector.Name,
+		Namespace: namespace,
+	}, &configMap); err != nil {
+		return nil, fmt.Errorf("getting file from ConfigMap %s/%s: %w", namespace, selector.Name, err)
+	}
+	if content, ok := configMap.BinaryData[selector.Key]; ok {
+		return content, nil
+	}
+	if content, ok := configMap.Data[selector.Key]; ok {
+		return []byte(content), nil
+	}
+	return nil, fmt.Errorf("key %q not found in ConfigMap %s/%s", selector.Key, namespace, selector.Name)
+}
+
+func (r *ChallengeDescriptionReconciler) getSecretContent(ctx context.Context, namespace string, selector v1alpha1.ChallengeFileKeySelector) ([]byte, error) {
+	var secret corev1.Secret
+	if err := r.GetClient().Get(ctx, client.ObjectKey{
+		Name:      selector.Name,
+		Namespace: namespace,
+	}, &secret); err != nil {
+		return nil, fmt.Errorf("getting file from Secret %s/%s: %w", namespace, selector.Name, err)
+	}
+	content, ok := secret.Data[selector.Key]
+	if !ok {
+		return nil, fmt.Errorf("key %q not found in Secret %s/%s", selector.Key, namespace, selector.Name)
+	}
+	return content, nil
+}
+
+// getMinioFile returns the given Minio object as file. The object is only downloaded when its ETag differs from the
+// one in the bookkeeping. Otherwise, the hash is taken over from the bookkeeping and the content is left empty.
+func (r *ChallengeDescriptionReconciler) getMinioFile(ctx context.Context, ctfd *v1alpha1.CTFd, name string, selector v1alpha1.ChallengeFileObjectSelector, fileStatuses []v1alpha1.FileStatus) (desiredFile, error) {
+	if r.minioEndpoint == nil {
+		return desiredFile{}, errors.New("files from Minio require a Minio endpoint strategy")
+	}
+	accessKeyID, secretAccessKey, err := GetMinioCredentials(ctx, r.GetClient(), ctfd)
+	if err != nil {
+		return desiredFile{}, err
+	}
+	minioEndpoint, err := r.minioEndpoint.GetEndpoint(ctx, ctfd)
+	if err != nil {
+		return desiredFile{}, err
+	}
+	minioClient, err := minio.New(minioEndpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKeyID, secretAccessKey, ""),
+		Secure: false,
+	})
+	if err != nil {
+		return desiredFile{}, err
+	}
+
+	objectInfo, err := minioClient.StatObject(ctx, ctfd.Name, selector.Object, minio.StatObjectOptions{})
+	if err != nil {
+		return desiredFile{}, fmt.Errorf("getting file from Minio object %q: %w", selector.Object, err)
+	}
+	if fileStatusIdx := slices.IndexFunc(fileStatuses, func(fileStatus v1alpha1.FileStatus) bool {
+		return fileStatus.Name == name && fileStatus.ETag == objectInfo.ETag
+	}); fileStatusIdx != -1 {
+		return desiredFile{
+			Name: name,
+			Hash: fileStatuses[fileStatusIdx].Hash,
+			ETag: objectInfo.ETag,
+		}, nil
+	}
+
+	object, err := minioClient.GetObject(ctx, ctfd.Name, selector.Object, minio.GetObjectOptions{})
+	if err != nil {
+		return desiredFile{}, fmt.Errorf("getting file from Minio object %q: %w", selector.Object, err)
+	}
+	defer object.Close() //nolint:errcheck
+
+	content, err := io.ReadAll(object)
+	if err != nil {
+		return desiredFile{}, fmt.Errorf("reading file from Minio object %q: %w", selector.Object, err)
+	}
+	hash := sha256.Sum256(content)
+	return desiredFile{
+		Name:    name,
+		Hash:    hex.EncodeToString(hash[:]),
+		ETag:    objectInfo.ETag,
+		Content: content,
+	}, nil
+}
+
+// MapFileSourceToCTFds returns reconcile requests for all CTFd instances which are reconciling a ChallengeDescription
+// referencing the given ConfigMap or Secret in its files annotation. Changed content needs to be uploaded again.
+func (r *ChallengeDescriptionReconciler) MapFileSourceToCTFds(ctx context.Context, fileSource client.Object) []reconcile.Request {
+	var challengeDescriptionList v1alpha2.ChallengeDescriptionList
+	if err := r.GetClient().List(ctx, &challengeDescriptionList, client.InNamespace(fileSource.GetNamespace())); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Listing ChallengeDescriptions for file source failed.")
+		return nil
+	}
+	if !slices.ContainsFunc(challengeDescriptionList.Items, func(k8sChallenge v1alpha2.ChallengeDescription) bool {
+		return r.referencesFileSource(&k8sChallenge, fileSource)
+	}) {
+		return nil
+	}
+
+	// ConfigMaps and Secrets are located in the namespace of the ChallengeDescription referencing them, so they are
+	// mapped the same way.
+	return r.MapChallengeDescriptionToCTFds(ctx, fileSource)
+}
+
+// referencesFileSource returns true if the files annotation of the ChallengeDescription references the given ConfigMap
+// or Secret.
+func (r *ChallengeDescriptionReconciler) referencesFileSource(k8sChallenge *v1alpha2.ChallengeDescription, fileSource client.Object) bool {
+	var k8sFiles []v1alpha1.ChallengeFile
+	if _, err := getJSONAnnotation(k8sChallenge, v1alpha1.ChallengeFilesAnnotation, &k8sFiles); err != nil {
+		return false
+	}
+	return slices.ContainsFunc(k8sFiles, func(k8sFile v1alpha1.ChallengeFile) bool {
+		switch fileSource.(type) {
+		case *corev1.ConfigMap:
+			return k8sFile.ConfigMap != nil && k8sFile.ConfigMap.Name == fileSource.GetName()
+		case *corev1.Secret:
+			return k8sFile.Secret != nil && k8sFile.Secret.Name == fileSource.GetName()
+		default:
+			return false
+		}
+	})
+}
+
+// filesDrifted returns true if the files in CTFd differ from the files uploaded by the operator.
+func (r *ChallengeDescriptionReconciler) filesDrifted(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdChallenge ctfdapi.Challenge, challengeStatus v1alpha1.ChallengeDescriptionStatus, k8sChallenge *v1alpha2.ChallengeDescription) (bool, error) {
+	if _, ok := k8sChallenge.Annotations[v1alpha1.ChallengeFilesAnnotation]; !ok && len(challengeStatus.Files) == 0 {
+		return false, nil
+	}
+	ctfdFiles, err := ctfdClient.ListFilesForChallenge(ctx, ctfdChallenge.Id)
+	if err != nil {
+		return false, err
+	}
+	var ctfdFileIds []int
+	for _, ctfdFile := range ctfdFiles {
+		ctfdFileIds = append(ctfdFileIds, ctfdFile.Id)
+	}
+	var statusFileIds []int
+	for _, fileStatus := range challengeStatus.Files {
+		statusFileIds = append(statusFileIds, fileStatus.Id)
+	}
+	slices.Sort(ctfdFileIds)
+	slices.Sort(statusFileIds)
+	return !slices.Equal(ctfdFileIds, statusFileIds), nil
+}
@@ -0,0 +1,154 @@
+package ctfd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	v1alpha2 "github.com/backbone81/ctf-challenge-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/backbone81/ctf-ui-operator/api/v1alpha1"
+	"github.com/backbone81/ctf-ui-operator/internal/ctfdapi"
+)
+
+// flagDataCaseInsensitive is the value CTFd stores in the data field of case-insensitive flags.
+const flagDataCaseInsensitive = "case_insensitive"
+
+// reconcileFlags makes sure that the challenge in CTFd has exactly the flags from the ChallengeDescription. Flags are
+// matched by type and content, so that adding or removing a flag does not touch any other flag.
+func (r *ChallengeDescriptionReconciler) reconcileFlags(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdChallenge ctfdapi.Challenge, ctfd *v1alpha1.CTFd, k8sChallenge *v1alpha2.ChallengeDescription) error {
+	desiredFlags, err := r.getDesiredFlags(k8sChallenge)
+	if err != nil {
+		return err
+	}
+
+	ctfdFlags, err := ctfdClient.ListFlagsForChallenge(ctx, ctfdChallenge.Id)
+	if err != nil {
+		return err
+	}
+
+	// Update existing flags and create missing flags
+	var usedFlagIds []int
+	for _, desiredFlag := range desiredFlags {
+		ctfdFlagIndex := slices.IndexFunc(ctfdFlags, func(ctfdFlag ctfdapi.Flag) bool {
+			return r.isSameFlag(ctfdFlag, desiredFlag) && !slices.Contains(usedFlagIds, ctfdFlag.Id)
+		})
+		if ctfdFlagIndex == -1 {
+			ctrl.LoggerFrom(ctx).Info(
+				"Creating flag",
+				"challenge-id", ctfdChallenge.Id,
+				"challenge-name", ctfdChallenge.Name,
+			)
+			desiredFlag.ChallengeId = ctfdChallenge.Id
+			flag, err := ctfdClient.CreateFlag(ctx, desiredFlag)
+			if err != nil {
+				return err
+			}
+			r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "FlagCreated", "Created flag with id %d for challenge %q", flag.Id, ctfdChallenge.Name)
+			usedFlagIds = append(usedFlagIds, flag.Id)
+			continue
+		}
+
+		ctfdFlag := ctfdFlags[ctfdFlagIndex]
+		usedFlagIds = append(usedFlagIds, ctfdFlag.Id)
+		if ctfdFlag.Type == desiredFlag.Type && ctfdFlag.Data == desiredFlag.Data {
+			continue
+		}
+		ctrl.LoggerFrom(ctx).Info(
+			"Updating flag",
+			"id", ctfdFlag.Id,
+			"challenge-id", ctfdChallenge.Id,
+			"challenge-name", ctfdChallenge.Name,
+		)
+		ctfdFlag.Type = desiredFlag.Type
+		ctfdFlag.Data = desiredFlag.Data
+		if _, err := ctfdClient.UpdateFlag(ctx, ctfdFlag); err != nil {
+			return err
+		}
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "FlagUpdated", "Updated flag with id %d of challenge %q", ctfdFlag.Id, ctfdChallenge.Name)
+	}
+
+	// Delete obsolete flags
+	for _, ctfdFlag := range ctfdFlags {
+		if slices.Contains(usedFlagIds, ctfdFlag.Id) {
+			continue
+		}
+		ctrl.LoggerFrom(ctx).Info(
+			"Deleting flag",
+			"id", ctfdFlag.Id,
+			"challenge-id", ctfdChallenge.Id,
+			"challenge-name", ctfdChallenge.Name,
+		)
+		if err := ctfdapi.IgnoreNotFound(ctfdClient.DeleteFlag(ctx, ctfdFlag.Id)); err != nil {
+			return err
+		}
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "FlagDeleted", "Deleted flag with id %d of challenge %q", ctfdFlag.Id, ctfdChallenge.Name)
+	}
+	return nil
+}
+
+// getDesiredFlags returns the flags as they should be stored in CTFd. The flag from the spec always comes first,
+// followed by the flags from the annotation.
+func (r *ChallengeDescriptionReconciler) getDesiredFlags(k8sChallenge *v1alpha2.ChallengeDescription) ([]ctfdapi.Flag, error) {
+	result := []ctfdapi.Flag{
+		{
+			Type:    v1alpha1.FlagTypeStatic,
+			Content: k8sChallenge.Spec.Flag,
+		},
+	}
+
+	var k8sFlags []v1alpha1.ChallengeFlag
+	if _, err := getJSONAnnotation(k8sChallenge, v1alpha1.ChallengeFlagsAnnotation, &k8sFlags); err != nil {
+		return nil, err
+	}
+	for _, k8sFlag := range k8sFlags {
+		flag := ctfdapi.Flag{
+			Type:    k8sFlag.Type,
+			Content: k8sFlag.Content,
+		}
+		switch flag.Type {
+		case "":
+			flag.Type = v1alpha1.FlagTypeStatic
+		case v1alpha1.FlagTypeStatic, v1alpha1.FlagTypeRegex:
+		default:
+			return nil, fmt.Errorf("parsing annotation %s: unknown flag type %q", v1alpha1.ChallengeFlagsAnnotation, k8sFlag.Type)
+		}
+		if k8sFlag.CaseInsensitive {
+			flag.Data = flagDataCaseInsensitive
+		}
+		result = append(result, flag)
+	}
+	return result, nil
+}
+
+// isSameFlag returns true if both flags have the same type and content. Flags created by older versions of the
+// operator have no type, which CTFd treats as static.
+func (r *ChallengeDescriptionReconciler) isSameFlag(ctfdFlag ctfdapi.Flag, desiredFlag ctfdapi.Flag) bool {
+	ctfdFlagType := ctfdFlag.Type
+	if ctfdFlagType == "" {
+		ctfdFlagType = v1alpha1.FlagTypeStatic
+	}
+	return ctfdFlagType == desiredFlag.Type && ctfdFlag.Content == desiredFlag.Content
+}
+
+// flagsDrifted returns true if the flags in CTFd differ from the desired flags.
+func (r *ChallengeDescriptionReconciler) flagsDrifted(ctfdFlags []ctfdapi.Flag, desiredFlags []ctfdapi.Flag) bool {
+	if len(ctfdFlags) != len(desiredFlags) {
+		return true
+	}
+	var usedFlagIds []int
+	for _, desiredFlag := range desiredFlags {
+		ctfdFlagIndex := slices.IndexFunc(ctfdFlags, func(ctfdFlag ctfdapi.Flag) bool {
+			return r.isSameFlag(ctfdFlag, desiredFlag) &&
+				ctfdFlag.Data == desiredFlag.Data &&
+				!slices.Contains(usedFlagIds, ctfdFlag.Id)
+		})
+		if ctfdFlagIndex == -1 {
+			return true
+		}
+		usedFlagIds = append(usedFlagIds, ctfdFlags[ctfdFlagIndex].Id)
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package ctfd
+
+import (
+	"fmt"
+	"slices"
+
+	v1alpha2 "github.com/backbone81/ctf-challenge-operator/api/v1alpha1"
+
+	"github.com/backbone81/ctf-ui-operator/api/v1alpha1"
+	"github.com/backbone81/ctf-ui-operator/internal/ctfdapi"
+)
+
+// freeHintTitle is the title of hints without cost when no title was configured. Players can see from the title that
+// unlocking the hint does not cost them any points.
+const freeHintTitle = "Free hint"
+
+// getHintSettings returns the settings from the hints annotation with one entry for every hint of the
+// ChallengeDescription. The entries of the annotation are matched to the hints by their description. Hints without an
+// entry get the default settings.
+func (r *ChallengeDescriptionReconciler) getHintSettings(k8sChallenge *v1alpha2.ChallengeDescription) ([]v1alpha1.ChallengeHintSettings, error) {
+	var k8sHintSettings []v1alpha1.ChallengeHintSettings
+	if _, err := getJSONAnnotation(k8sChallenge, v1alpha1.ChallengeHintsAnnotation, &k8sHintSettings); err != nil {
+		return nil, err
+	}
+
+	result := make([]v1alpha1.ChallengeHintSettings, len(k8sChallenge.Spec.Hints))
+	for k8sHintSettingsIdx, hintSettings := range k8sHintSettings {
+		if slices.ContainsFunc(k8sHintSettings[:k8sHintSettingsIdx], func(otherHintSettings v1alpha1.ChallengeHintSettings) bool {
+			return otherHintSettings.Description == hintSettings.Description
+		}) {
+			return nil, fmt.Errorf("parsing annotation %s: duplicate settings for hint %q", v1alpha1.ChallengeHintsAnnotation, hintSettings.Description)
+		}
+
+		// Hints with the same description share their settings.
+		found := false
+		for k8sHintIdx, k8sHint := range k8sChallenge.Spec.Hints {
+			if k8sHint.Description != hintSettings.Description {
+				continue
+			}
+			if k8sHintIdx == 0 && hintSettings.RequiresPrevious {
+				return nil, fmt.Errorf("parsing annotation %s: the first hint can not require a previous hint", v1alpha1.ChallengeHintsAnnotation)
+			}
+			result[k8sHintIdx] = hintSettings
+			found = true
+		}
+		if !found {
+			return nil, fmt.Errorf("parsing annotation %s: no hint with the description %q", v1alpha1.ChallengeHintsAnnotation, hintSettings.Description)
+		}
+	}
+	return result, nil
+}
+
+// getDesiredHints returns the hints as they should be stored in CTFd in the order of the ChallengeDescription. The
+// requirements are not part of the result, as they depend on the ids of other hints.
+func (r *ChallengeDescriptionReconciler) getDesiredHints(k8sHints []v1alpha2.ChallengeHint, hintSettings []v1alpha1.ChallengeHintSettings) []ctfdapi.Hint {
+	result := make([]ctfdapi.Hint, 0, len(k8sHints))
+	for k8sHintIdx, k8sHint := range k8sHints {
+		hint := ctfdapi.Hint{
+			Title:   hintSettings[k8sHintIdx].Title,
+			Type:    ctfdapi.HintTypeStandard,
+			Content: k8sHint.Description,
+			Cost:    k8sHint.Cost,
+		}
+		if len(hint.Title) == 0 && hint.Cost == 0 {
+			hint.Title = freeHintTitle
+		}
+		result = append(result, hint)
+	}
+	return result
+}
+
+// getDesiredHintRequirements returns the requirements of the hint at the given position. The previous hint is
+// resolved through the bookkeeping. If the previous hint was not created yet, the requirement is added on the next
+// reconcile.
+func (r *ChallengeDescriptionReconciler) getDesiredHintRequirements(challengeStatus v1alpha1.ChallengeDescriptionStatus, hintSettings []v1alpha1.ChallengeHintSettings, hintKeys []string, hintIdx int) *ctfdapi.HintRequirements {
+	result := &ctfdapi.HintRequirements{
+		Prerequisites: []int{},
+	}
+	if !hintSettings[hintIdx].RequiresPrevious || hintIdx == 0 {
+		return result
+	}
+	hintStatusIdx := slices.IndexFunc(challengeStatus.Hints, func(hintStatus v1alpha1.HintStatus) bool {
+		return hintStatus.Key == hintKeys[hintIdx-1]
+	})
+	if hintStatusIdx != -1 {
+		result.Prerequisites = append(result.Prerequisites, challengeStatus.Hints[hintStatusIdx].Id)
+	}
+	return result
+}
+
+// isSameHint returns true if the hint in CTFd matches the desired hint. Hints created by older versions of the
+// operator have no type, which CTFd treats as standard.
+func (r *ChallengeDescriptionReconciler) isSameHint(ctfdHint ctfdapi.Hint, desiredHint ctfdapi.Hint) bool {
+	ctfdHintType := ctfdHint.Type
+	if ctfdHintType == "" {
+		ctfdHintType = ctfdapi.HintTypeStandard
+	}
+	var ctfdPrerequisites []int
+	if ctfdHint.Requirements != nil {
+		ctfdPrerequisites = ctfdHint.Requirements.Prerequisites
+	}
+	var desiredPrerequisites []int
+	if desiredHint.Requirements != nil {
+		desiredPrerequisites = desiredHint.Requirements.Prerequisites
+	}
+	return ctfdHint.Title == desiredHint.Title &&
+		ctfdHintType == desiredHint.Type &&
+		ctfdHint.Content == desiredHint.Content &&
+		ctfdHint.Cost == desiredHint.Cost &&
+		slices.Equal(slices.Sorted(slices.Values(ctfdPrerequisites)), slices.Sorted(slices.Values(desiredPrerequisites)))
+}
@@ -0,0 +1,50 @@
+package ctfd
+
+import (
+	"fmt"
+	"time"
+
+	v1alpha2 "github.com/backbone81/ctf-challenge-operator/api/v1alpha1"
+
+	"github.com/backbone81/ctf-ui-operator/api/v1alpha1"
+)
+
+// getReleaseTime returns the point in time when the challenge is released. It returns nil if the challenge has no
+// release time and is therefore released right away.
+func getReleaseTime(k8sChallenge *v1alpha2.ChallengeDescription) (*time.Time, error) {
+	value, ok := k8sChallenge.Annotations[v1alpha1.ChallengeReleaseTimeAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	releaseTime, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("parsing annotation %s: %w", v1alpha1.ChallengeReleaseTimeAnnotation, err)
+	}
+	return &releaseTime, nil
+}
+
+// isReleased returns true if the release time of the challenge has passed or the challenge has no release time.
+func isReleased(k8sChallenge *v1alpha2.ChallengeDescription, now time.Time) (bool, error) {
+	releaseTime, err := getReleaseTime(k8sChallenge)
+	if err != nil {
+		return false, err
+	}
+	return releaseTime == nil || !now.Before(*releaseTime), nil
+}
+
+// getNextReleaseTime returns the earliest release time of all challenges which are not released yet. It returns nil
+// if there is no challenge waiting for its release. Invalid release times are ignored here, as they are reported when
+// the challenge itself is reconciled.
+func getNextReleaseTime(k8sChallenges []v1alpha2.ChallengeDescription, now time.Time) *time.Time {
+	var result *time.Time
+	for i := range k8sChallenges {
+		releaseTime, err := getReleaseTime(&k8sChallenges[i])
+		if err != nil || releaseTime == nil || !now.Before(*releaseTime) {
+			continue
+		}
+		if result == nil || releaseTime.Before(*result) {
+			result = releaseTime
+		}
+	}
+	return result
+}
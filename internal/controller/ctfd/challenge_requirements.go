@@ -0,0 +1,100 @@
+package ctfd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	v1alpha2 "github.com/backbone81/ctf-challenge-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/backbone81/ctf-ui-operator/api/v1alpha1"
+	"github.com/backbone81/ctf-ui-operator/internal/ctfdapi"
+)
+
+// reconcileRequirements sets the prerequisites of all challenges. This needs to happen after all challenges were
+// created, as the prerequisites reference other challenges by their CTFd id. A challenge with prerequisites which can
+// not be resolved keeps its current requirements, as unlocking it too early is worse than unlocking it too late.
+func (r *ChallengeDescriptionReconciler) reconcileRequirements(ctx context.Context, ctfdClient *ctfdapi.Client, ctfd *v1alpha1.CTFd, k8sChallenges []v1alpha2.ChallengeDescription) error {
+	var errs []error
+	for _, challengeStatus := range ctfd.Status.ChallengeDescriptions {
+		k8sChallengeIndex := r.getK8sChallengeIndex(k8sChallenges, challengeStatus)
+		if k8sChallengeIndex == -1 {
+			continue
+		}
+		if err := r.reconcileRequirement(ctx, ctfdClient, ctfd, challengeStatus, &k8sChallenges[k8sChallengeIndex]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *ChallengeDescriptionReconciler) reconcileRequirement(ctx context.Context, ctfdClient *ctfdapi.Client, ctfd *v1alpha1.CTFd, challengeStatus v1alpha1.ChallengeDescriptionStatus, k8sChallenge *v1alpha2.ChallengeDescription) error {
+	desiredPrerequisites, err := r.getDesiredPrerequisites(ctfd, k8sChallenge)
+	if err != nil {
+		return err
+	}
+
+	requirements, err := ctfdClient.GetChallengeRequirements(ctx, challengeStatus.Id)
+	if err != nil {
+		return err
+	}
+	currentPrerequisites := slices.Sorted(slices.Values(requirements.Prerequisites))
+	if slices.Equal(currentPrerequisites, desiredPrerequisites) {
+		return nil
+	}
+
+	ctrl.LoggerFrom(ctx).Info(
+		"Updating challenge requirements",
+		"id", challengeStatus.Id,
+		"prerequisites", desiredPrerequisites,
+	)
+	requirements.Prerequisites = desiredPrerequisites
+	if err := ctfdClient.UpdateChallengeRequirements(ctx, challengeStatus.Id, requirements); err != nil {
+		return err
+	}
+	r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "RequirementsUpdated", "Updated prerequisites of challenge with id %d to %v", challengeStatus.Id, desiredPrerequisites)
+	return nil
+}
+
+// getDesiredPrerequisites resolves the ChallengeDescriptions referenced by the prerequisites annotation into sorted
+// CTFd challenge ids through the bookkeeping.
+func (r *ChallengeDescriptionReconciler) getDesiredPrerequisites(ctfd *v1alpha1.CTFd, k8sChallenge *v1alpha2.ChallengeDescription) ([]int, error) {
+	value, ok := k8sChallenge.Annotations[v1alpha1.ChallengePrerequisitesAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	var result []int
+	for _, reference := range strings.Split(value, ",") {
+		reference = strings.TrimSpace(reference)
+		if len(reference) == 0 {
+			continue
+		}
+		key := client.ObjectKey{
+			Namespace: k8sChallenge.Namespace,
+			Name:      reference,
+		}
+		if namespace, name, found := strings.Cut(reference, "/"); found {
+			key.Namespace = namespace
+			key.Name = name
+		}
+		if key == client.ObjectKeyFromObject(k8sChallenge) {
+			return nil, fmt.Errorf("challenge %s can not be its own prerequisite", key)
+		}
+
+		statusIndex := slices.IndexFunc(ctfd.Status.ChallengeDescriptions, func(challengeStatus v1alpha1.ChallengeDescriptionStatus) bool {
+			return challengeStatus.Namespace == key.Namespace && challengeStatus.Name == key.Name
+		})
+		if statusIndex == -1 {
+			return nil, fmt.Errorf("prerequisite %s of challenge %s is not reconciled into this CTFd instance", key, client.ObjectKeyFromObject(k8sChallenge))
+		}
+		result = append(result, ctfd.Status.ChallengeDescriptions[statusIndex].Id)
+	}
+	slices.Sort(result)
+	return slices.Compact(result), nil
+}
@@ -0,0 +1,168 @@
+package ctfd
+
+import (
+	"context"
+	"slices"
+
+	v1alpha2 "github.com/backbone81/ctf-challenge-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/backbone81/ctf-ui-operator/api/v1alpha1"
+	"github.com/backbone81/ctf-ui-operator/internal/ctfdapi"
+)
+
+// reconcileTags makes sure that the challenge in CTFd has exactly the tags from the tags annotation. Tags are matched
+// by value. Without the annotation, the tags are not managed by the operator.
+func (r *ChallengeDescriptionReconciler) reconcileTags(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdChallenge ctfdapi.Challenge, ctfd *v1alpha1.CTFd, k8sChallenge *v1alpha2.ChallengeDescription) error {
+	desiredTags, ok := getListAnnotation(k8sChallenge, v1alpha1.ChallengeTagsAnnotation)
+	if !ok {
+		return nil
+	}
+
+	ctfdTags, err := ctfdClient.ListTagsForChallenge(ctx, ctfdChallenge.Id)
+	if err != nil {
+		return err
+	}
+
+	// Create missing tags
+	var usedTagIds []int
+	for _, desiredTag := range desiredTags {
+		ctfdTagIndex := slices.IndexFunc(ctfdTags, func(ctfdTag ctfdapi.Tag) bool {
+			return ctfdTag.Value == desiredTag && !slices.Contains(usedTagIds, ctfdTag.Id)
+		})
+		if ctfdTagIndex != -1 {
+			usedTagIds = append(usedTagIds, ctfdTags[ctfdTagIndex].Id)
+			continue
+		}
+		ctrl.LoggerFrom(ctx).Info(
+			"Creating tag",
+			"value", desiredTag,
+			"challenge-id", ctfdChallenge.Id,
+			"challenge-name", ctfdChallenge.Name,
+		)
+		tag, err := ctfdClient.CreateTag(ctx, ctfdapi.Tag{
+			ChallengeId: ctfdChallenge.Id,
+			Value:       desiredTag,
+		})
+		if err != nil {
+			return err
+		}
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "TagCreated", "Created tag %q for challenge %q", tag.Value, ctfdChallenge.Name)
+		usedTagIds = append(usedTagIds, tag.Id)
+	}
+
+	// Delete obsolete tags
+	for _, ctfdTag := range ctfdTags {
+		if slices.Contains(usedTagIds, ctfdTag.Id) {
+			continue
+		}
+		ctrl.LoggerFrom(ctx).Info(
+			"Deleting tag",
+			"id", ctfdTag.Id,
+			"challenge-id", ctfdChallenge.Id,
+			"challenge-name", ctfdChallenge.Name,
+		)
+		if err := ctfdapi.IgnoreNotFound(ctfdClient.DeleteTag(ctx, ctfdTag.Id)); err != nil {
+			return err
+		}
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "TagDeleted", "Deleted tag %q of challenge %q", ctfdTag.Value, ctfdChallenge.Name)
+	}
+	return nil
+}
+
+// reconcileTopics makes sure that the challenge in CTFd has exactly the topics from the topics annotation. Topics are
+// matched by value. Removing a topic from a challenge keeps the topic itself, as other challenges might still use it.
+// Without the annotation, the topics are not managed by the operator.
+func (r *ChallengeDescriptionReconciler) reconcileTopics(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdChallenge ctfdapi.Challenge, ctfd *v1alpha1.CTFd, k8sChallenge *v1alpha2.ChallengeDescription) error {
+	desiredTopics, ok := getListAnnotation(k8sChallenge, v1alpha1.ChallengeTopicsAnnotation)
+	if !ok {
+		return nil
+	}
+
+	ctfdTopics, err := ctfdClient.ListTopicsForChallenge(ctx, ctfdChallenge.Id)
+	if err != nil {
+		return err
+	}
+
+	// Create missing topics
+	var usedTopicIds []int
+	for _, desiredTopic := range desiredTopics {
+		ctfdTopicIndex := slices.IndexFunc(ctfdTopics, func(ctfdTopic ctfdapi.ChallengeTopic) bool {
+			return ctfdTopic.Value == desiredTopic && !slices.Contains(usedTopicIds, ctfdTopic.Id)
+		})
+		if ctfdTopicIndex != -1 {
+			usedTopicIds = append(usedTopicIds, ctfdTopics[ctfdTopicIndex].Id)
+			continue
+		}
+		ctrl.LoggerFrom(ctx).Info(
+			"Creating topic",
+			"value", desiredTopic,
+			"challenge-id", ctfdChallenge.Id,
+			"challenge-name", ctfdChallenge.Name,
+		)
+		topic, err := ctfdClient.CreateChallengeTopic(ctx, ctfdChallenge.Id, desiredTopic)
+		if err != nil {
+			return err
+		}
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "TopicCreated", "Created topic %q for challenge %q", desiredTopic, ctfdChallenge.Name)
+		usedTopicIds = append(usedTopicIds, topic.Id)
+	}
+
+	// Delete obsolete topics
+	for _, ctfdTopic := range ctfdTopics {
+		if slices.Contains(usedTopicIds, ctfdTopic.Id) {
+			continue
+		}
+		ctrl.LoggerFrom(ctx).Info(
+			"Deleting topic",
+			"id", ctfdTopic.Id,
+			"challenge-id", ctfdChallenge.Id,
+			"challenge-name", ctfdChallenge.Name,
+		)
+		if err := ctfdapi.IgnoreNotFound(ctfdClient.DeleteChallengeTopic(ctx, ctfdTopic.Id)); err != nil {
+			return err
+		}
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "TopicDeleted", "Deleted topic %q of challenge %q", ctfdTopic.Value, ctfdChallenge.Name)
+	}
+	return nil
+}
+
+// tagsDrifted returns true if the tags in CTFd differ from the tags annotation.
+func (r *ChallengeDescriptionReconciler) tagsDrifted(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdChallenge ctfdapi.Challenge, k8sChallenge *v1alpha2.ChallengeDescription) (bool, error) {
+	desiredTags, ok := getListAnnotation(k8sChallenge, v1alpha1.ChallengeTagsAnnotation)
+	if !ok {
+		return false, nil
+	}
+	ctfdTags, err := ctfdClient.ListTagsForChallenge(ctx, ctfdChallenge.Id)
+	if err != nil {
+		return false, err
+	}
+	var ctfdValues []string
+	for _, ctfdTag := range ctfdTags {
+		ctfdValues = append(ctfdValues, ctfdTag.Value)
+	}
+	return !sameValues(ctfdValues, desiredTags), nil
+}
+
+// topicsDrifted returns true if the topics in CTFd differ from the topics annotation.
+func (r *ChallengeDescriptionReconciler) topicsDrifted(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdChallenge ctfdapi.Challenge, k8sChallenge *v1alpha2.ChallengeDescription) (bool, error) {
+	desiredTopics, ok := getListAnnotation(k8sChallenge, v1alpha1.ChallengeTopicsAnnotation)
+	if !ok {
+		return false, nil
+	}
+	ctfdTopics, err := ctfdClient.ListTopicsForChallenge(ctx, ctfdChallenge.Id)
+	if err != nil {
+		return false, err
+	}
+	var ctfdValues []string
+	for _, ctfdTopic := range ctfdTopics {
+		ctfdValues = append(ctfdValues, ctfdTopic.Value)
+	}
+	return !sameValues(ctfdValues, desiredTopics), nil
+}
+
+// sameValues returns true if both lists contain the same values regardless of their order.
+func sameValues(current []string, desired []string) bool {
+	return slices.Equal(slices.Sorted(slices.Values(current)), slices.Sorted(slices.Values(desired)))
+}
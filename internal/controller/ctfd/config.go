@@ -0,0 +1,203 @@
+package ctfd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/backbone81/ctf-ui-operator/api/v1alpha1"
+	"github.com/backbone81/ctf-ui-operator/internal/ctfdapi"
+	"github.com/backbone81/ctf-ui-operator/internal/utils"
+)
+
+// booleanConfigKeys are config keys which CTFd interprets as booleans. CTFd does not store booleans in a consistent
+// way, so we need to normalize them before comparing.
+var booleanConfigKeys = []string{
+	"verify_emails",
+}
+
+// ConfigReconciler is responsible for keeping the configuration of a CTFd instance in sync with the CTFd resource
+// after the initial setup was done.
+type ConfigReconciler struct {
+	utils.DefaultSubReconciler
+	ctfdEndpoint CTFdEndpointStrategy
+}
+
+func NewConfigReconciler(client client.Client, recorder record.EventRecorder, options ...SubReconcilerOption) *ConfigReconciler {
+	result := &ConfigReconciler{
+		DefaultSubReconciler: utils.NewDefaultSubReconciler(client, recorder),
+	}
+	for _, option := range options {
+		option(result)
+	}
+
+	if result.ctfdEndpoint == nil {
+		panic("CTFd endpoint strategy required")
+	}
+	return result
+}
+
+func (r *ConfigReconciler) Blocking() bool {
+	// Challenges can be synced even when the configuration could not be applied.
+	return false
+}
+
+func (r *ConfigReconciler) Reconcile(ctx context.Context, ctfd *v1alpha1.CTFd) (ctrl.Result, error) {
+	if !ctfd.Status.Ready {
+		// The CTFd instance is not ready. We try again later when the instance is up and running. The next reconcile
+		// will be triggered when the status changes.
+		ctrl.LoggerFrom(ctx).V(1).Info("CTFd is not ready, skipping ConfigReconciler.")
+		return ctrl.Result{}, nil
+	}
+
+	adminDetails, err := GetAdminDetails(ctx, r.GetClient(), ctfd)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(adminDetails.AccessToken) == 0 {
+		// Without an access token we cannot use the API. The access token reconciler will create one.
+		ctrl.LoggerFrom(ctx).V(1).Info("No access token available, skipping ConfigReconciler.")
+		return ctrl.Result{}, nil
+	}
+
+	endpoint, err := r.ctfdEndpoint.GetEndpoint(ctx, ctfd)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	ctfdClient, err := ctfdapi.NewClient(endpoint, adminDetails.AccessToken)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.reconcileConfigs(ctx, ctfdClient, ctfd); err != nil {
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeWarning, "ConfigSyncFailed", "Failed to sync the configuration: %s", err)
+		return ctrl.Result{}, errors.Join(err, r.SetCondition(
+			ctx,
+			ctfd,
+			v1alpha1.ConditionTypeConfigSynced,
+			metav1.ConditionFalse,
+			"ConfigSyncFailed",
+			"Failed to sync the configuration, check the ConfigSyncFailed events for details.",
+		))
+	}
+	return ctrl.Result{}, r.SetCondition(
+		ctx,
+		ctfd,
+		v1alpha1.ConditionTypeConfigSynced,
+		metav1.ConditionTrue,
+		"ConfigSynced",
+		"The CTFd configuration matches the spec.",
+	)
+}
+
+func (r *ConfigReconciler) reconcileConfigs(ctx context.Context, ctfdClient *ctfdapi.Client, ctfd *v1alpha1.CTFd) error {
+	currentConfigs, err := ctfdClient.ListConfigs(ctx)
+	if err != nil {
+		return fmt.Errorf("listing configs: %w", err)
+	}
+
+	changedConfigs := r.getChangedConfigs(currentConfigs, r.getDesiredConfigs(ctfd))
+	if len(changedConfigs) == 0 {
+		return nil
+	}
+
+	ctrl.LoggerFrom(ctx).Info(
+		"Updating config",
+		"keys", slices.Sorted(maps.Keys(changedConfigs)),
+	)
+	if err := ctfdClient.UpdateConfigs(ctx, changedConfigs); err != nil {
+		return fmt.Errorf("updating configs: %w", err)
+	}
+	return nil
+}
+
+// getDesiredConfigs returns the config keys with the values as they should be set in CTFd. The user mode is
+// intentionally missing, as CTFd requires a full reset of the instance when changing the user mode.
+func (r *ConfigReconciler) getDesiredConfigs(ctfd *v1alpha1.CTFd) map[string]*string {
+	result := map[string]*string{
+		"ctf_name":                ptr.To(ctfd.Spec.Title),
+		"ctf_description":         ptr.To(ctfd.Spec.Description),
+		"challenge_visibility":    ptr.To(ctfd.Spec.ChallengeVisibility),
+		"account_visibility":      ptr.To(ctfd.Spec.AccountVisibility),
+		"score_visibility":        ptr.To(ctfd.Spec.ScoreVisibility),
+		"registration_visibility": ptr.To(ctfd.Spec.RegistrationVisibility),
+		"verify_emails":           ptr.To(strconv.FormatBool(ctfd.Spec.VerifyEmails)),
+		"ctf_theme":               ptr.To(ctfd.Spec.Theme),
+		"team_size":               nil,
+		"start":                   nil,
+		"end":                     nil,
+	}
+	if ctfd.Spec.TeamSize != nil {
+		result["team_size"] = ptr.To(strconv.Itoa(*ctfd.Spec.TeamSize))
+	}
+	if ctfd.Spec.Start != nil {
+		result["start"] = ptr.To(strconv.FormatInt(ctfd.Spec.Start.Unix(), 10))
+	}
+	if ctfd.Spec.End != nil {
+		result["end"] = ptr.To(strconv.FormatInt(ctfd.Spec.End.Unix(), 10))
+	}
+	if ctfd.Spec.ThemeColor != nil {
+		// The theme color is not stored as a dedicated config key. The setup process renders it into the theme header
+		// instead. We do the same here. Without a theme color, we leave the theme header alone, as it might have been
+		// customized manually.
+		result["theme_header"] = ptr.To(themeHeader(*ctfd.Spec.ThemeColor))
+	}
+	return result
+}
+
+// getChangedConfigs returns all desired configs which differ from the current configs.
+func (r *ConfigReconciler) getChangedConfigs(currentConfigs []ctfdapi.Config, desiredConfigs map[string]*string) map[string]*string {
+	result := make(map[string]*string)
+	for key, desiredValue := range desiredConfigs {
+		var currentValue *string
+		currentConfigIndex := slices.IndexFunc(currentConfigs, func(config ctfdapi.Config) bool {
+			return config.Key == key
+		})
+		if currentConfigIndex != -1 {
+			currentValue = currentConfigs[currentConfigIndex].Value
+		}
+		if r.normalizeConfigValue(key, currentValue) == r.normalizeConfigValue(key, desiredValue) {
+			continue
+		}
+		result[key] = desiredValue
+	}
+	return result
+}
+
+func (r *ConfigReconciler) normalizeConfigValue(key string, value *string) string {
+	normalizedValue := ptr.Deref(value, "")
+	if slices.Contains(booleanConfigKeys, key) {
+		switch strings.ToLower(normalizedValue) {
+		case "1", "true", "y", "yes":
+			return "true"
+		default:
+			return "false"
+		}
+	}
+	return normalizedValue
+}
+
+func (r *ConfigReconciler) SetCTFdEndpoint(endpoint CTFdEndpointStrategy) {
+	r.ctfdEndpoint = endpoint
+}
+
+// themeHeader renders the theme header for the given theme color the same way the CTFd setup does.
+func themeHeader(themeColor string) string {
+	return "<style id=\"theme-color\">\n" +
+		":root {--theme-color: " + themeColor + ";}\n" +
+		".navbar{background-color: var(--theme-color) !important;}\n" +
+		".jumbotron{background-color: var(--theme-color) !important;}\n" +
+		"</style>\n"
+}
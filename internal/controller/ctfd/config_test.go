@@ -0,0 +1,97 @@
+package ctfd_test
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/backbone81/ctf-ui-operator/api/v1alpha1"
+	"github.com/backbone81/ctf-ui-operator/internal/controller/ctfd"
+	"github.com/backbone81/ctf-ui-operator/internal/ctfdapi"
+	"github.com/backbone81/ctf-ui-operator/internal/testutils"
+	"github.com/backbone81/ctf-ui-operator/internal/utils"
+)
+
+var _ = Describe("ConfigReconciler", func() {
+	var (
+		reconciler *utils.Reconciler[*v1alpha1.CTFd]
+		ctfdClient *ctfdapi.Client
+	)
+
+	BeforeEach(func() {
+		reconciler = ctfd.NewReconciler(k8sClient, recorder, ctfd.WithConfigReconciler(WithCTFdTestEndpoint(endpointUrl)))
+		var err error
+		ctfdClient, err = ctfdapi.NewClient(endpointUrl, accessToken)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func(ctx SpecContext) {
+		DeleteAllInstances(ctx)
+		setupRequest := GetDefaultSetupRequest()
+		Expect(ctfdClient.UpdateConfigs(ctx, map[string]*string{
+			"ctf_name":         ptr.To(setupRequest.CTFName),
+			"score_visibility": ptr.To(string(setupRequest.ScoreVisibility)),
+		})).To(Succeed())
+	})
+
+	It("should update changed configs", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				Title:           "Modified CTF",
+				ScoreVisibility: "public",
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+
+		By("run the reconciler")
+		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		By("verify all postconditions")
+		config, err := ctfdClient.GetConfig(ctx, "ctf_name")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(config.Value).To(HaveValue(Equal("Modified CTF")))
+		config, err = ctfdClient.GetConfig(ctx, "score_visibility")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(config.Value).To(HaveValue(Equal("public")))
+	})
+
+	It("should skip when no access token is available", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.CTFdSpec{
+				Title: "Modified CTF",
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, nil)).To(Succeed())
+
+		By("run the reconciler")
+		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		By("verify all postconditions")
+		config, err := ctfdClient.GetConfig(ctx, "ctf_name")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(config.Value).To(HaveValue(Equal(GetDefaultSetupRequest().CTFName)))
+	})
+})
@@ -9,6 +9,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -30,9 +31,9 @@ type DeploymentReconciler struct {
 	utils.DefaultSubReconciler
 }
 
-func NewDeploymentReconciler(client client.Client) *DeploymentReconciler {
+func NewDeploymentReconciler(client client.Client, recorder record.EventRecorder) *DeploymentReconciler {
 	return &DeploymentReconciler{
-		DefaultSubReconciler: utils.NewDefaultSubReconciler(client),
+		DefaultSubReconciler: utils.NewDefaultSubReconciler(client, recorder),
 	}
 }
 
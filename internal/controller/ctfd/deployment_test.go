@@ -21,7 +21,7 @@ var _ = Describe("DeploymentReconciler", func() {
 	var reconciler *utils.Reconciler[*v1alpha1.CTFd]
 
 	BeforeEach(func() {
-		reconciler = ctfd.NewReconciler(k8sClient, ctfd.WithDeploymentReconciler())
+		reconciler = ctfd.NewReconciler(k8sClient, recorder, ctfd.WithDeploymentReconciler())
 	})
 
 	AfterEach(func(ctx SpecContext) {
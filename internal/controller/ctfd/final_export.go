@@ -0,0 +1,147 @@
+package ctfd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/backbone81/ctf-ui-operator/api/v1alpha1"
+	"github.com/backbone81/ctf-ui-operator/internal/ctfdapi"
+	"github.com/backbone81/ctf-ui-operator/internal/utils"
+)
+
+// FinalExportReconciler is responsible for uploading a full export of the CTFd instance to some S3 compatible
+// storage when the CTFd resource is deleted.
+type FinalExportReconciler struct {
+	utils.DefaultSubReconciler
+	ctfdEndpoint CTFdEndpointStrategy
+}
+
+var _ utils.SubFinalizer[*v1alpha1.CTFd] = (*FinalExportReconciler)(nil)
+
+func NewFinalExportReconciler(client client.Client, recorder record.EventRecorder, options ...SubReconcilerOption) *FinalExportReconciler {
+	result := &FinalExportReconciler{
+		DefaultSubReconciler: utils.NewDefaultSubReconciler(client, recorder),
+	}
+	for _, option := range options {
+		option(result)
+	}
+
+	if result.ctfdEndpoint == nil {
+		panic("CTFd endpoint strategy required")
+	}
+	return result
+}
+
+func (r *FinalExportReconciler) Blocking() bool {
+	// The export only happens on deletion and does not affect any other sub-reconciler.
+	return false
+}
+
+func (r *FinalExportReconciler) Reconcile(ctx context.Context, ctfd *v1alpha1.CTFd) (ctrl.Result, error) {
+	// The export is only done when the CTFd resource is deleted.
+	return ctrl.Result{}, nil
+}
+
+func (r *FinalExportReconciler) Finalize(ctx context.Context, ctfd *v1alpha1.CTFd) (ctrl.Result, error) {
+	if ctfd.Spec.FinalExport == nil {
+		ctrl.LoggerFrom(ctx).V(1).Info("No final export configured, skipping FinalExportReconciler finalizer.")
+		return ctrl.Result{}, nil
+	}
+	if !ctfd.Status.Ready {
+		// We do not want to block the deletion forever when CTFd is broken. The export is best effort.
+		ctrl.LoggerFrom(ctx).Info("CTFd is not ready, skipping final export.")
+		r.GetRecorder().Event(ctfd, corev1.EventTypeWarning, "FinalExportSkipped", "Skipped final export because CTFd is not ready")
+		return ctrl.Result{}, nil
+	}
+
+	objectName, err := r.export(ctx, ctfd)
+	if err != nil {
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeWarning, "FinalExportFailed", "Failed to export CTFd: %s", err)
+		return ctrl.Result{}, err
+	}
+	r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "FinalExportStored", "Stored final export as %q in bucket %q", objectName, ctfd.Spec.FinalExport.Bucket)
+	return ctrl.Result{}, nil
+}
+
+func (r *FinalExportReconciler) export(ctx context.Context, ctfd *v1alpha1.CTFd) (string, error) {
+	adminDetails, err := GetAdminDetails(ctx, r.GetClient(), ctfd)
+	if err != nil {
+		return "", err
+	}
+
+	endpoint, err := r.ctfdEndpoint.GetEndpoint(ctx, ctfd)
+	if err != nil {
+		return "", err
+	}
+
+	ctfdClient, err := ctfdapi.NewClient(endpoint, adminDetails.AccessToken)
+	if err != nil {
+		return "", err
+	}
+
+	minioClient, err := r.getStorageClient(ctx, ctfd)
+	if err != nil {
+		return "", err
+	}
+
+	ctrl.LoggerFrom(ctx).Info("Exporting CTFd")
+	data, err := ctfdClient.Export(ctx)
+	if err != nil {
+		return "", fmt.Errorf("exporting CTFd: %w", err)
+	}
+
+	objectName := fmt.Sprintf("%s-%s-%s.zip", ctfd.Namespace, ctfd.Name, time.Now().UTC().Format("20060102T150405Z"))
+	ctrl.LoggerFrom(ctx).Info("Uploading final export", "bucket", ctfd.Spec.FinalExport.Bucket, "object", objectName)
+	if _, err := minioClient.PutObject(
+		ctx,
+		ctfd.Spec.FinalExport.Bucket,
+		objectName,
+		bytes.NewReader(data),
+		int64(len(data)),
+		minio.PutObjectOptions{
+			ContentType: "application/zip",
+		},
+	); err != nil {
+		return "", fmt.Errorf("uploading final export: %w", err)
+	}
+	return objectName, nil
+}
+
+func (r *FinalExportReconciler) getStorageClient(ctx context.Context, ctfd *v1alpha1.CTFd) (*minio.Client, error) {
+	var secret corev1.Secret
+	if err := r.GetClient().Get(ctx, client.ObjectKey{
+		Name:      ctfd.Spec.FinalExport.SecretName,
+		Namespace: ctfd.Namespace,
+	}, &secret); err != nil {
+		return nil, err
+	}
+
+	if len(secret.Data["AWS_ACCESS_KEY_ID"]) == 0 {
+		return nil, errors.New("AWS_ACCESS_KEY_ID is empty in final export secret")
+	}
+	accessKeyId := string(secret.Data["AWS_ACCESS_KEY_ID"])
+
+	if len(secret.Data["AWS_SECRET_ACCESS_KEY"]) == 0 {
+		return nil, errors.New("AWS_SECRET_ACCESS_KEY is empty in final export secret")
+	}
+	secretAccessKey := string(secret.Data["AWS_SECRET_ACCESS_KEY"])
+
+	return minio.New(ctfd.Spec.FinalExport.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKeyId, secretAccessKey, ""),
+		Secure: ctfd.Spec.FinalExport.Secure,
+	})
+}
+
+func (r *FinalExportReconciler) SetCTFdEndpoint(endpoint CTFdEndpointStrategy) {
+	r.ctfdEndpoint = endpoint
+}
@@ -0,0 +1,102 @@
+package ctfd_test
+
+import (
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/testcontainers/testcontainers-go"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/backbone81/ctf-ui-operator/api/v1alpha1"
+	"github.com/backbone81/ctf-ui-operator/internal/controller/ctfd"
+	"github.com/backbone81/ctf-ui-operator/internal/testutils"
+	"github.com/backbone81/ctf-ui-operator/internal/utils"
+)
+
+var _ = Describe("FinalExportReconciler", func() {
+	var (
+		container     testcontainers.Container
+		minioEndpoint string
+		minioClient   *minio.Client
+
+		reconciler *utils.Reconciler[*v1alpha1.CTFd]
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		var err error
+		container, err = testutils.NewMinioTestContainer(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		minioEndpoint, err = container.Endpoint(ctx, "")
+		Expect(err).ToNot(HaveOccurred())
+
+		minioClient, err = minio.New(minioEndpoint, &minio.Options{
+			Creds:  credentials.NewStaticV4(testutils.MinioUser, testutils.MinioPassword, ""),
+			Secure: false,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(minioClient.MakeBucket(ctx, "exports", minio.MakeBucketOptions{})).To(Succeed())
+
+		reconciler = ctfd.NewReconciler(k8sClient, recorder, ctfd.WithFinalExportReconciler(WithCTFdTestEndpoint(endpointUrl)))
+	})
+
+	AfterEach(func(ctx SpecContext) {
+		Expect(container.Terminate(ctx)).To(Succeed())
+		DeleteAllInstances(ctx)
+	})
+
+	It("should upload the export when the CTFd is deleted", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+
+		exportSecret := corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      instance.Name + "-export",
+				Namespace: instance.Namespace,
+			},
+			Data: map[string][]byte{
+				"AWS_ACCESS_KEY_ID":     []byte(testutils.MinioUser),
+				"AWS_SECRET_ACCESS_KEY": []byte(testutils.MinioPassword),
+			},
+		}
+		Expect(k8sClient.Create(ctx, &exportSecret)).To(Succeed())
+		instance.Spec.FinalExport = &v1alpha1.FinalExportSpec{
+			Endpoint:   minioEndpoint,
+			Bucket:     "exports",
+			SecretName: exportSecret.Name,
+		}
+		Expect(k8sClient.Update(ctx, &instance)).To(Succeed())
+
+		Expect(reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))).To(BeZero())
+		Expect(k8sClient.Delete(ctx, &instance)).To(Succeed())
+
+		By("run the reconciler")
+		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		By("verify all postconditions")
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).ToNot(Succeed())
+
+		var objects []minio.ObjectInfo
+		for object := range minioClient.ListObjects(ctx, "exports", minio.ListObjectsOptions{}) {
+			Expect(object.Err).ToNot(HaveOccurred())
+			objects = append(objects, object)
+		}
+		Expect(objects).To(ConsistOf(HaveField("Key", HavePrefix(instance.Namespace+"-"+instance.Name+"-"))))
+		Expect(RecordedEvents()).To(ContainElement(HavePrefix("Normal FinalExportStored")))
+	})
+})
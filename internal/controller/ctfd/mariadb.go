@@ -6,6 +6,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -21,9 +22,9 @@ type MariaDBReconciler struct {
 	utils.DefaultSubReconciler
 }
 
-func NewMariaDBReconciler(client client.Client) *MariaDBReconciler {
+func NewMariaDBReconciler(client client.Client, recorder record.EventRecorder) *MariaDBReconciler {
 	return &MariaDBReconciler{
-		DefaultSubReconciler: utils.NewDefaultSubReconciler(client),
+		DefaultSubReconciler: utils.NewDefaultSubReconciler(client, recorder),
 	}
 }
 
@@ -31,6 +32,12 @@ func (r *MariaDBReconciler) SetupWithManager(ctrlBuilder *builder.Builder) *buil
 	return ctrlBuilder.Owns(&v1alpha1.MariaDB{})
 }
 
+func (r *MariaDBReconciler) Blocking() bool {
+	// Redis and Minio can be created without MariaDB. The Secret and Deployment still wait for it, as
+	// WithBackendReconcilers puts all backends into one blocking group.
+	return false
+}
+
 func (r *MariaDBReconciler) Reconcile(ctx context.Context, ctfd *v1alpha1.CTFd) (ctrl.Result, error) {
 	currentSpec, err := r.getMariaDB(ctx, ctfd)
 	if err != nil {
@@ -20,7 +20,7 @@ var _ = Describe("MariaDBReconciler", func() {
 	var reconciler *utils.Reconciler[*v1alpha1.CTFd]
 
 	BeforeEach(func() {
-		reconciler = ctfd.NewReconciler(k8sClient, ctfd.WithMariaDBReconciler())
+		reconciler = ctfd.NewReconciler(k8sClient, recorder, ctfd.WithMariaDBReconciler())
 	})
 
 	AfterEach(func(ctx SpecContext) {
@@ -6,6 +6,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -21,9 +22,9 @@ type MinioReconciler struct {
 	utils.DefaultSubReconciler
 }
 
-func NewMinioReconciler(client client.Client) *MinioReconciler {
+func NewMinioReconciler(client client.Client, recorder record.EventRecorder) *MinioReconciler {
 	return &MinioReconciler{
-		DefaultSubReconciler: utils.NewDefaultSubReconciler(client),
+		DefaultSubReconciler: utils.NewDefaultSubReconciler(client, recorder),
 	}
 }
 
@@ -31,6 +32,12 @@ func (r *MinioReconciler) SetupWithManager(ctrlBuilder *builder.Builder) *builde
 	return ctrlBuilder.Owns(&v1alpha1.Minio{})
 }
 
+func (r *MinioReconciler) Blocking() bool {
+	// MariaDB and Redis can be created without Minio. The Secret needs the Minio credentials, which is why the
+	// backend group as a whole blocks the sub-reconcilers after it.
+	return false
+}
+
 func (r *MinioReconciler) Reconcile(ctx context.Context, ctfd *v1alpha1.CTFd) (ctrl.Result, error) {
 	currentSpec, err := r.getMinio(ctx, ctfd)
 	if err != nil {
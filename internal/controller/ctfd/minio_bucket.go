@@ -7,6 +7,8 @@ import (
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -23,9 +25,9 @@ type MinioBucketReconciler struct {
 	minioEndpoint MinioEndpointStrategy
 }
 
-func NewMinioBucketReconciler(client client.Client, options ...SubReconcilerOption) *MinioBucketReconciler {
+func NewMinioBucketReconciler(client client.Client, recorder record.EventRecorder, options ...SubReconcilerOption) *MinioBucketReconciler {
 	result := &MinioBucketReconciler{
-		DefaultSubReconciler: utils.NewDefaultSubReconciler(client),
+		DefaultSubReconciler: utils.NewDefaultSubReconciler(client, recorder),
 	}
 	for _, option := range options {
 		option(result)
@@ -41,6 +43,11 @@ func (r *MinioBucketReconciler) SetupWithManager(ctrlBuilder *builder.Builder) *
 	return ctrlBuilder.Owns(&v1alpha1.Minio{})
 }
 
+func (r *MinioBucketReconciler) Blocking() bool {
+	// A missing bucket only breaks file uploads. It must not prevent the rest of CTFd from being deployed.
+	return false
+}
+
 func (r *MinioBucketReconciler) Reconcile(ctx context.Context, ctfd *v1alpha1.CTFd) (ctrl.Result, error) {
 	currentSpec, err := r.getMinio(ctx, ctfd)
 	if err != nil {
@@ -49,46 +56,83 @@ func (r *MinioBucketReconciler) Reconcile(ctx context.Context, ctfd *v1alpha1.CT
 	if currentSpec == nil {
 		// The minio instance is not available yet. We will get triggered later again.
 		ctrl.LoggerFrom(ctx).V(1).Info("Minio not found, skipping MinioBucketReconciler.")
-		return ctrl.Result{}, nil
+		return ctrl.Result{}, r.SetCondition(
+			ctx,
+			ctfd,
+			v1alpha1.ConditionTypeBucketReady,
+			metav1.ConditionFalse,
+			"MinioNotFound",
+			"Waiting for Minio to be created.",
+		)
 	}
 
 	if !currentSpec.Status.Ready {
 		// The Minio instance is not ready. We try again later when the instance is up and running. The next reconcile
 		// will be triggered when the status changes.
 		ctrl.LoggerFrom(ctx).V(1).Info("Minio is not ready, skipping MinioBucketReconciler.")
-		return ctrl.Result{}, nil
-	}
+		return ctrl.Result{}, r.SetCondition(
+			ctx,
+			ctfd,
+			v1alpha1.ConditionTypeBucketReady,
+			metav1.ConditionFalse,
+			"MinioNotReady",
+			"Waiting for Minio to become ready.",
+		)
+	}
+
+	if err := r.reconcileBucket(ctx, ctfd); err != nil {
+		return ctrl.Result{}, errors.Join(err, r.SetCondition(
+			ctx,
+			ctfd,
+			v1alpha1.ConditionTypeBucketReady,
+			metav1.ConditionFalse,
+			"BucketNotAvailable",
+			"The bucket "+ctfd.Name+" is not available, check the operator logs for details.",
+		))
+	}
+	return ctrl.Result{}, r.SetCondition(
+		ctx,
+		ctfd,
+		v1alpha1.ConditionTypeBucketReady,
+		metav1.ConditionTrue,
+		"BucketAvailable",
+		"The bucket "+ctfd.Name+" exists.",
+	)
+}
 
-	accessKeyID, secretAccessKey, err := r.getMinioCredentials(ctx, ctfd)
+func (r *MinioBucketReconciler) reconcileBucket(ctx context.Context, ctfd *v1alpha1.CTFd) error {
+	accessKeyID, secretAccessKey, err := GetMinioCredentials(ctx, r.GetClient(), ctfd)
 	if err != nil {
-		return ctrl.Result{}, err
+		return err
 	}
 	minioEndpoint, err := r.minioEndpoint.GetEndpoint(ctx, ctfd)
 	if err != nil {
-		return ctrl.Result{}, err
+		return err
 	}
 	minioClient, err := minio.New(minioEndpoint, &minio.Options{
 		Creds:  credentials.NewStaticV4(accessKeyID, secretAccessKey, ""),
 		Secure: false,
 	})
 	if err != nil {
-		return ctrl.Result{}, err
+		return err
 	}
 
 	exists, err := minioClient.BucketExists(ctx, ctfd.Name)
 	if err != nil {
-		return ctrl.Result{}, err
+		return err
 	}
 	if exists {
 		// We can exit early, as the bucket already exists.
-		return ctrl.Result{}, nil
+		return nil
 	}
 
 	ctrl.LoggerFrom(ctx).Info("Creating Minio bucket", "bucket", ctfd.Name)
 	if err := minioClient.MakeBucket(ctx, ctfd.Name, minio.MakeBucketOptions{}); err != nil {
-		return ctrl.Result{}, err
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeWarning, "BucketCreationFailed", "Failed to create bucket %q: %s", ctfd.Name, err)
+		return err
 	}
-	return ctrl.Result{}, nil
+	r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "BucketCreated", "Created bucket %q", ctfd.Name)
+	return nil
 }
 
 func (r *MinioBucketReconciler) getMinio(ctx context.Context, ctfd *v1alpha1.CTFd) (*v1alpha1.Minio, error) {
@@ -102,9 +146,14 @@ func (r *MinioBucketReconciler) getMinio(ctx context.Context, ctfd *v1alpha1.CTF
 	return &minio, nil
 }
 
-func (r *MinioBucketReconciler) getMinioCredentials(ctx context.Context, ctfd *v1alpha1.CTFd) (string, string, error) {
+func (r *MinioBucketReconciler) SetMinioEndpoint(endpoint MinioEndpointStrategy) {
+	r.minioEndpoint = endpoint
+}
+
+// GetMinioCredentials returns the access key id and the secret access key of the Minio instance of the CTFd instance.
+func GetMinioCredentials(ctx context.Context, k8sClient client.Client, ctfd *v1alpha1.CTFd) (string, string, error) {
 	var secret corev1.Secret
-	if err := r.GetClient().Get(ctx, client.ObjectKey{
+	if err := k8sClient.Get(ctx, client.ObjectKey{
 		Name:      MinioName(ctfd),
 		Namespace: ctfd.Namespace,
 	}, &secret); err != nil {
@@ -123,7 +172,3 @@ func (r *MinioBucketReconciler) getMinioCredentials(ctx context.Context, ctfd *v
 
 	return accessKeyId, secretAccessKey, nil
 }
-
-func (r *MinioBucketReconciler) SetMinioEndpoint(endpoint MinioEndpointStrategy) {
-	r.minioEndpoint = endpoint
-}
@@ -6,6 +6,7 @@ import (
 	"github.com/testcontainers/testcontainers-go"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -33,7 +34,7 @@ var _ = Describe("MinioBucketReconciler", func() {
 		Expect(err).ToNot(HaveOccurred())
 		endpointUrl = endpoint
 
-		reconciler = ctfd.NewReconciler(k8sClient, ctfd.WithMinioBucketReconciler(WithMinioTestEndpoint(endpointUrl)))
+		reconciler = ctfd.NewReconciler(k8sClient, recorder, ctfd.WithMinioBucketReconciler(WithMinioTestEndpoint(endpointUrl)))
 	})
 
 	AfterEach(func(ctx SpecContext) {
@@ -71,5 +72,12 @@ var _ = Describe("MinioBucketReconciler", func() {
 		afterBuckets, err := minioClient.ListBuckets(ctx)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(afterBuckets).To(HaveLen(len(beforeBuckets) + 1))
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		Expect(instance.Status.Conditions).To(ContainElement(And(
+			HaveField("Type", v1alpha1.ConditionTypeBucketReady),
+			HaveField("Status", metav1.ConditionTrue),
+		)))
+		Expect(RecordedEvents()).To(ContainElement(HavePrefix("Normal BucketCreated")))
 	})
 })
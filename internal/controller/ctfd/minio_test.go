@@ -20,7 +20,7 @@ var _ = Describe("MinioReconciler", func() {
 	var reconciler *utils.Reconciler[*v1alpha1.CTFd]
 
 	BeforeEach(func() {
-		reconciler = ctfd.NewReconciler(k8sClient, ctfd.WithMinioReconciler())
+		reconciler = ctfd.NewReconciler(k8sClient, recorder, ctfd.WithMinioReconciler())
 	})
 
 	AfterEach(func(ctx SpecContext) {
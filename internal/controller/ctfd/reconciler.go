@@ -1,6 +1,7 @@
 package ctfd
 
 import (
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/backbone81/ctf-ui-operator/api/v1alpha1"
@@ -11,9 +12,10 @@ import (
 // +kubebuilder:rbac:groups=ui.ctf.backbone81,resources=ctfds/finalizers,verbs=update
 // +kubebuilder:rbac:groups=ui.ctf.backbone81,resources=ctfds/status,verbs=get;update;patch
 
-func NewReconciler(client client.Client, options ...utils.ReconcilerOption[*v1alpha1.CTFd]) *utils.Reconciler[*v1alpha1.CTFd] {
+func NewReconciler(client client.Client, recorder record.EventRecorder, options ...utils.ReconcilerOption[*v1alpha1.CTFd]) *utils.Reconciler[*v1alpha1.CTFd] {
 	return utils.NewReconciler[*v1alpha1.CTFd](
 		client,
+		recorder,
 		func() *v1alpha1.CTFd {
 			return &v1alpha1.CTFd{}
 		},
@@ -26,9 +28,7 @@ func WithDefaultReconcilers() utils.ReconcilerOption[*v1alpha1.CTFd] {
 	return func(reconciler *utils.Reconciler[*v1alpha1.CTFd]) {
 		WithStatusReconciler()(reconciler)
 
-		WithMariaDBReconciler()(reconciler)
-		WithRedisReconciler()(reconciler)
-		WithMinioReconciler()(reconciler)
+		WithBackendReconcilers()(reconciler)
 		WithMinioBucketReconciler(WithMinioAutodetectEndpoint())(reconciler)
 
 		WithServiceAccountReconciler()(reconciler)
@@ -39,84 +39,125 @@ func WithDefaultReconcilers() utils.ReconcilerOption[*v1alpha1.CTFd] {
 		WithAdminSecretReconciler()(reconciler)
 		WithSetupReconciler(WithCTFdAutodetectEndpoint())(reconciler)
 		WithAccessTokenReconciler(WithCTFdAutodetectEndpoint())(reconciler)
-		WithChallengeDescriptionReconciler(WithCTFdAutodetectEndpoint())(reconciler)
+		WithConfigReconciler(WithCTFdAutodetectEndpoint())(reconciler)
+		WithChallengeDescriptionReconciler(WithCTFdAutodetectEndpoint(), WithMinioAutodetectEndpoint())(reconciler)
+		WithRosterReconciler(WithCTFdAutodetectEndpoint())(reconciler)
+		WithAwardReconciler(WithCTFdAutodetectEndpoint())(reconciler)
+
+		// Sub-reconcilers are finalized in reverse order. The final export therefore runs before anything is removed
+		// from CTFd.
+		WithFinalExportReconciler(WithCTFdAutodetectEndpoint())(reconciler)
 	}
 }
 
 func WithAccessTokenReconciler(options ...SubReconcilerOption) utils.ReconcilerOption[*v1alpha1.CTFd] {
 	return func(reconciler *utils.Reconciler[*v1alpha1.CTFd]) {
-		reconciler.AppendSubReconciler(NewAccessTokenReconciler(reconciler.GetClient(), options...))
+		reconciler.AppendSubReconciler(NewAccessTokenReconciler(reconciler.GetClient(), reconciler.GetRecorder(), options...))
 	}
 }
 
 func WithAdminSecretReconciler() utils.ReconcilerOption[*v1alpha1.CTFd] {
 	return func(reconciler *utils.Reconciler[*v1alpha1.CTFd]) {
-		reconciler.AppendSubReconciler(NewAdminSecretReconciler(reconciler.GetClient()))
+		reconciler.AppendSubReconciler(NewAdminSecretReconciler(reconciler.GetClient(), reconciler.GetRecorder()))
 	}
 }
 
+func WithAwardReconciler(options ...SubReconcilerOption) utils.ReconcilerOption[*v1alpha1.CTFd] {
+	return func(reconciler *utils.Reconciler[*v1alpha1.CTFd]) {
+		reconciler.AppendSubReconciler(NewAwardReconciler(reconciler.GetClient(), reconciler.GetRecorder(), options...))
+	}
+}
+
+// WithBackendReconcilers adds the MariaDB, Redis and Minio reconcilers as one group. The backends are created
+// independently of each other, but the CTFd deployment needs all of them.
+func WithBackendReconcilers() utils.ReconcilerOption[*v1alpha1.CTFd] {
+	return utils.WithSubReconcilerGroup(
+		WithMariaDBReconciler(),
+		WithRedisReconciler(),
+		WithMinioReconciler(),
+	)
+}
+
 func WithChallengeDescriptionReconciler(options ...SubReconcilerOption) utils.ReconcilerOption[*v1alpha1.CTFd] {
 	return func(reconciler *utils.Reconciler[*v1alpha1.CTFd]) {
-		reconciler.AppendSubReconciler(NewChallengeDescriptionReconciler(reconciler.GetClient(), options...))
+		reconciler.AppendSubReconciler(NewChallengeDescriptionReconciler(reconciler.GetClient(), reconciler.GetRecorder(), options...))
+	}
+}
+
+func WithConfigReconciler(options ...SubReconcilerOption) utils.ReconcilerOption[*v1alpha1.CTFd] {
+	return func(reconciler *utils.Reconciler[*v1alpha1.CTFd]) {
+		reconciler.AppendSubReconciler(NewConfigReconciler(reconciler.GetClient(), reconciler.GetRecorder(), options...))
 	}
 }
 
 func WithDeploymentReconciler() utils.ReconcilerOption[*v1alpha1.CTFd] {
 	return func(reconciler *utils.Reconciler[*v1alpha1.CTFd]) {
-		reconciler.AppendSubReconciler(NewDeploymentReconciler(reconciler.GetClient()))
+		reconciler.AppendSubReconciler(NewDeploymentReconciler(reconciler.GetClient(), reconciler.GetRecorder()))
+	}
+}
+
+func WithFinalExportReconciler(options ...SubReconcilerOption) utils.ReconcilerOption[*v1alpha1.CTFd] {
+	return func(reconciler *utils.Reconciler[*v1alpha1.CTFd]) {
+		reconciler.AppendSubReconciler(NewFinalExportReconciler(reconciler.GetClient(), reconciler.GetRecorder(), options...))
 	}
 }
 
 func WithMariaDBReconciler() utils.ReconcilerOption[*v1alpha1.CTFd] {
 	return func(reconciler *utils.Reconciler[*v1alpha1.CTFd]) {
-		reconciler.AppendSubReconciler(NewMariaDBReconciler(reconciler.GetClient()))
+		reconciler.AppendSubReconciler(NewMariaDBReconciler(reconciler.GetClient(), reconciler.GetRecorder()))
 	}
 }
 
 func WithMinioReconciler() utils.ReconcilerOption[*v1alpha1.CTFd] {
 	return func(reconciler *utils.Reconciler[*v1alpha1.CTFd]) {
-		reconciler.AppendSubReconciler(NewMinioReconciler(reconciler.GetClient()))
+		reconciler.AppendSubReconciler(NewMinioReconciler(reconciler.GetClient(), reconciler.GetRecorder()))
 	}
 }
 
 func WithMinioBucketReconciler(options ...SubReconcilerOption) utils.ReconcilerOption[*v1alpha1.CTFd] {
 	return func(reconciler *utils.Reconciler[*v1alpha1.CTFd]) {
-		reconciler.AppendSubReconciler(NewMinioBucketReconciler(reconciler.GetClient(), options...))
+		reconciler.AppendSubReconciler(NewMinioBucketReconciler(reconciler.GetClient(), reconciler.GetRecorder(), options...))
 	}
 }
 
 func WithRedisReconciler() utils.ReconcilerOption[*v1alpha1.CTFd] {
 	return func(reconciler *utils.Reconciler[*v1alpha1.CTFd]) {
-		reconciler.AppendSubReconciler(NewRedisReconciler(reconciler.GetClient()))
+		reconciler.AppendSubReconciler(NewRedisReconciler(reconciler.GetClient(), reconciler.GetRecorder()))
+	}
+}
+
+func WithRosterReconciler(options ...SubReconcilerOption) utils.ReconcilerOption[*v1alpha1.CTFd] {
+	return func(reconciler *utils.Reconciler[*v1alpha1.CTFd]) {
+		reconciler.AppendSubReconciler(NewRosterReconciler(reconciler.GetClient(), reconciler.GetRecorder(), options...))
 	}
 }
 
 func WithSecretReconciler() utils.ReconcilerOption[*v1alpha1.CTFd] {
 	return func(reconciler *utils.Reconciler[*v1alpha1.CTFd]) {
-		reconciler.AppendSubReconciler(NewSecretReconciler(reconciler.GetClient()))
+		reconciler.AppendSubReconciler(NewSecretReconciler(reconciler.GetClient(), reconciler.GetRecorder()))
 	}
 }
 
 func WithServiceReconciler() utils.ReconcilerOption[*v1alpha1.CTFd] {
 	return func(reconciler *utils.Reconciler[*v1alpha1.CTFd]) {
-		reconciler.AppendSubReconciler(NewServiceReconciler(reconciler.GetClient()))
+		reconciler.AppendSubReconciler(NewServiceReconciler(reconciler.GetClient(), reconciler.GetRecorder()))
 	}
 }
 
 func WithServiceAccountReconciler() utils.ReconcilerOption[*v1alpha1.CTFd] {
 	return func(reconciler *utils.Reconciler[*v1alpha1.CTFd]) {
-		reconciler.AppendSubReconciler(NewServiceAccountReconciler(reconciler.GetClient()))
+		reconciler.AppendSubReconciler(NewServiceAccountReconciler(reconciler.GetClient(), reconciler.GetRecorder()))
 	}
 }
 
 func WithSetupReconciler(options ...SubReconcilerOption) utils.ReconcilerOption[*v1alpha1.CTFd] {
 	return func(reconciler *utils.Reconciler[*v1alpha1.CTFd]) {
-		reconciler.AppendSubReconciler(NewSetupReconciler(reconciler.GetClient(), options...))
+		reconciler.AppendSubReconciler(NewSetupReconciler(reconciler.GetClient(), reconciler.GetRecorder(), options...))
 	}
 }
 
 func WithStatusReconciler() utils.ReconcilerOption[*v1alpha1.CTFd] {
 	return func(reconciler *utils.Reconciler[*v1alpha1.CTFd]) {
-		reconciler.AppendSubReconciler(NewStatusReconciler(reconciler.GetClient()))
+		reconciler.AppendSubReconciler(NewStatusReconciler(reconciler.GetClient(), reconciler.GetRecorder()))
 	}
 }
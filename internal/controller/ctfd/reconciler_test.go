@@ -1,8 +1,15 @@
 package ctfd_test
 
 import (
+	"context"
+	"errors"
+
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -17,7 +24,7 @@ var _ = Describe("Reconciler", func() {
 	var reconciler *utils.Reconciler[*v1alpha1.CTFd]
 
 	BeforeEach(func() {
-		reconciler = ctfd.NewReconciler(k8sClient, ctfd.WithDefaultReconcilers())
+		reconciler = ctfd.NewReconciler(k8sClient, recorder, ctfd.WithDefaultReconcilers())
 	})
 
 	AfterEach(func(ctx SpecContext) {
@@ -40,4 +47,75 @@ var _ = Describe("Reconciler", func() {
 		Expect(err).ToNot(HaveOccurred())
 		Expect(result).To(BeZero())
 	})
+
+	It("should continue with independent sub-reconcilers when one fails", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		reconciler = ctfd.NewReconciler(
+			k8sClient,
+			recorder,
+			// There is no Minio listening on this endpoint, which makes the bucket creation fail.
+			ctfd.WithMinioBucketReconciler(WithMinioTestEndpoint("127.0.0.1:1")),
+			ctfd.WithServiceReconciler(),
+		)
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		Expect(CreateRequiredThirdPartySecrets(ctx, &instance)).To(Succeed())
+		Expect(SetMinioReady(ctx, &instance, true)).To(Succeed())
+
+		By("run the reconciler")
+		_, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).To(HaveOccurred())
+
+		By("verify all postconditions")
+		var service corev1.Service
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &service)).To(Succeed())
+	})
+
+	It("should reconcile all backends but not deploy CTFd when a backend fails", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		watchClient, err := client.NewWithWatch(testEnv.Config, client.Options{Scheme: k8sClient.Scheme()})
+		Expect(err).ToNot(HaveOccurred())
+		failingClient := interceptor.NewClient(watchClient, interceptor.Funcs{
+			Create: func(ctx context.Context, withWatch client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if _, ok := obj.(*v1alpha1.MariaDB); ok {
+					return errors.New("creating MariaDB failed")
+				}
+				return withWatch.Create(ctx, obj, opts...)
+			},
+		})
+		reconciler = ctfd.NewReconciler(
+			failingClient,
+			recorder,
+			ctfd.WithBackendReconcilers(),
+			ctfd.WithSecretReconciler(),
+			ctfd.WithDeploymentReconciler(),
+		)
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		Expect(CreateRequiredThirdPartySecrets(ctx, &instance)).To(Succeed())
+
+		By("run the reconciler")
+		_, err = reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).To(HaveOccurred())
+
+		By("verify all postconditions")
+		var redis v1alpha1.Redis
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: instance.Namespace, Name: ctfd.RedisName(&instance)}, &redis)).To(Succeed())
+		var minio v1alpha1.Minio
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: instance.Namespace, Name: ctfd.MinioName(&instance)}, &minio)).To(Succeed())
+		var secret corev1.Secret
+		Expect(apierrors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &secret))).To(BeTrue())
+		var deployment appsv1.Deployment
+		Expect(apierrors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &deployment))).To(BeTrue())
+	})
 })
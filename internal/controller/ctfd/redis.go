@@ -6,6 +6,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -21,9 +22,9 @@ type RedisReconciler struct {
 	utils.DefaultSubReconciler
 }
 
-func NewRedisReconciler(client client.Client) *RedisReconciler {
+func NewRedisReconciler(client client.Client, recorder record.EventRecorder) *RedisReconciler {
 	return &RedisReconciler{
-		DefaultSubReconciler: utils.NewDefaultSubReconciler(client),
+		DefaultSubReconciler: utils.NewDefaultSubReconciler(client, recorder),
 	}
 }
 
@@ -31,6 +32,12 @@ func (r *RedisReconciler) SetupWithManager(ctrlBuilder *builder.Builder) *builde
 	return ctrlBuilder.Owns(&v1alpha1.Redis{})
 }
 
+func (r *RedisReconciler) Blocking() bool {
+	// MariaDB and Minio can be created without Redis. The blocking backend group keeps CTFd from being deployed
+	// before Redis is available.
+	return false
+}
+
 func (r *RedisReconciler) Reconcile(ctx context.Context, ctfd *v1alpha1.CTFd) (ctrl.Result, error) {
 	currentSpec, err := r.getRedis(ctx, ctfd)
 	if err != nil {
@@ -20,7 +20,7 @@ var _ = Describe("RedisReconciler", func() {
 	var reconciler *utils.Reconciler[*v1alpha1.CTFd]
 
 	BeforeEach(func() {
-		reconciler = ctfd.NewReconciler(k8sClient, ctfd.WithRedisReconciler())
+		reconciler = ctfd.NewReconciler(k8sClient, recorder, ctfd.WithRedisReconciler())
 	})
 
 	AfterEach(func(ctx SpecContext) {
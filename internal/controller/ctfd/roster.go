@@ -0,0 +1,295 @@
+package ctfd
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/backbone81/ctf-ui-operator/api/v1alpha1"
+	"github.com/backbone81/ctf-ui-operator/internal/ctfdapi"
+	"github.com/backbone81/ctf-ui-operator/internal/utils"
+)
+
+// +kubebuilder:rbac:groups=ui.ctf.backbone81,resources=teams,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ui.ctf.backbone81,resources=teams/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ui.ctf.backbone81,resources=participants,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ui.ctf.backbone81,resources=participants/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ui.ctf.backbone81,resources=participants/finalizers,verbs=update
+
+// RosterReconciler is responsible for reconciling Team and Participant resources into the instance.
+type RosterReconciler struct {
+	utils.DefaultSubReconciler
+	ctfdEndpoint CTFdEndpointStrategy
+}
+
+func NewRosterReconciler(client client.Client, recorder record.EventRecorder, options ...SubReconcilerOption) *RosterReconciler {
+	result := &RosterReconciler{
+		DefaultSubReconciler: utils.NewDefaultSubReconciler(client, recorder),
+	}
+	for _, option := range options {
+		option(result)
+	}
+
+	if result.ctfdEndpoint == nil {
+		panic("CTFd endpoint strategy required")
+	}
+	return result
+}
+
+func (r *RosterReconciler) SetupWithManager(ctrlBuilder *builder.Builder) *builder.Builder {
+	// Changes to the status are done by ourselves and do not need to trigger a reconcile.
+	return ctrlBuilder.Watches(
+		&v1alpha1.Team{},
+		handler.EnqueueRequestsFromMapFunc(r.MapRosterToCTFd),
+		builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+	).Watches(
+		&v1alpha1.Participant{},
+		handler.EnqueueRequestsFromMapFunc(r.MapRosterToCTFd),
+		builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+	)
+}
+
+// MapRosterToCTFd returns a reconcile request for the CTFd instance the given Team or Participant references.
+func (r *RosterReconciler) MapRosterToCTFd(ctx context.Context, obj client.Object) []reconcile.Request {
+	var ctfdName string
+	switch rosterObj := obj.(type) {
+	case *v1alpha1.Team:
+		ctfdName = rosterObj.Spec.CTFdName
+	case *v1alpha1.Participant:
+		ctfdName = rosterObj.Spec.CTFdName
+	default:
+		return nil
+	}
+	return []reconcile.Request{
+		{
+			NamespacedName: client.ObjectKey{
+				Namespace: obj.GetNamespace(),
+				Name:      ctfdName,
+			},
+		},
+	}
+}
+
+func (r *RosterReconciler) Blocking() bool {
+	// A broken team or participant must not prevent the other sub-reconcilers from running.
+	return false
+}
+
+func (r *RosterReconciler) Reconcile(ctx context.Context, ctfd *v1alpha1.CTFd) (ctrl.Result, error) {
+	k8sTeams, err := r.listK8sTeams(ctx, ctfd)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	k8sParticipants, err := r.listK8sParticipants(ctx, ctfd)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(k8sTeams) == 0 && len(k8sParticipants) == 0 &&
+		len(ctfd.Status.Teams) == 0 && len(ctfd.Status.Participants) == 0 {
+		ctrl.LoggerFrom(ctx).V(1).Info("No teams or participants provided, skipping RosterReconciler.")
+		return ctrl.Result{}, r.RemoveCondition(ctx, ctfd, v1alpha1.ConditionTypeRosterSynced)
+	}
+	if !ctfd.Status.Ready {
+		// The CTFd instance is not ready. We try again later when the instance is up and running. The next reconcile
+		// will be triggered when the status changes.
+		ctrl.LoggerFrom(ctx).V(1).Info("CTFd is not ready, skipping RosterReconciler.")
+		return ctrl.Result{}, nil
+	}
+
+	adminDetails, err := GetAdminDetails(ctx, r.GetClient(), ctfd)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	endpoint, err := r.ctfdEndpoint.GetEndpoint(ctx, ctfd)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	ctfdClient, err := ctfdapi.NewClient(endpoint, adminDetails.AccessToken)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	rosterErr := r.reconcileRoster(ctx, ctfdClient, ctfd, k8sTeams, k8sParticipants)
+	// The status of the Teams and Participants is also updated when the roster failed, so that the failure shows up
+	// there as well.
+	if err := r.updateRosterStatus(ctx, ctfd, k8sTeams, k8sParticipants, rosterErr); err != nil {
+		return ctrl.Result{}, errors.Join(rosterErr, err)
+	}
+	if err := rosterErr; err != nil {
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeWarning, "RosterSyncFailed", "Failed to sync teams and participants: %s", err)
+		return ctrl.Result{}, errors.Join(err, r.SetCondition(
+			ctx,
+			ctfd,
+			v1alpha1.ConditionTypeRosterSynced,
+			metav1.ConditionFalse,
+			"RosterSyncFailed",
+			"Failed to sync teams and participants, check the RosterSyncFailed events for details.",
+		))
+	}
+	return ctrl.Result{}, r.SetCondition(
+		ctx,
+		ctfd,
+		v1alpha1.ConditionTypeRosterSynced,
+		metav1.ConditionTrue,
+		"RosterSynced",
+		fmt.Sprintf("%d teams and %d participants are synced.", len(ctfd.Status.Teams), len(ctfd.Status.Participants)),
+	)
+}
+
+// reconcileRoster syncs all Teams and Participants into CTFd. Teams and users in CTFd which were not created by the
+// operator are left alone.
+func (r *RosterReconciler) reconcileRoster(ctx context.Context, ctfdClient *ctfdapi.Client, ctfd *v1alpha1.CTFd, k8sTeams []v1alpha1.Team, k8sParticipants []v1alpha1.Participant) error {
+	ctfdTeams, err := ctfdClient.ListTeams(ctx)
+	if err != nil {
+		return err
+	}
+	ctfdUsers, err := ctfdClient.ListUsers(ctx)
+	if err != nil {
+		return err
+	}
+
+	statusBefore := ctfd.Status.DeepCopy()
+	r.cleanupTeamStatus(ctfd, k8sTeams, ctfdTeams)
+	r.cleanupParticipantStatus(ctfd, k8sParticipants, ctfdUsers)
+
+	// Teams are reconciled before participants, as participants need the team to exist before they can join it.
+	if err := r.updateExistingTeams(ctx, ctfdClient, ctfdTeams, ctfd, k8sTeams); err != nil {
+		return err
+	}
+	if err := r.createMissingTeams(ctx, ctfdClient, ctfd, k8sTeams); err != nil {
+		return err
+	}
+	if err := r.updateExistingParticipants(ctx, ctfdClient, ctfdUsers, ctfd, k8sParticipants); err != nil {
+		return err
+	}
+	if err := r.createMissingParticipants(ctx, ctfdClient, ctfd, k8sParticipants); err != nil {
+		return err
+	}
+
+	// A participant referencing a missing team must not prevent the bookkeeping from being persisted. We report the
+	// error at the end.
+	membershipErr := r.reconcileMemberships(ctx, ctfdClient, ctfdUsers, ctfd, k8sParticipants)
+	if membershipErr == nil {
+		membershipErr = r.reconcileTeamCaptains(ctx, ctfdClient, ctfdTeams, ctfd)
+	}
+
+	if err := r.deleteObsoleteParticipants(ctx, ctfdClient, ctfdUsers, statusBefore.Participants, ctfd); err != nil {
+		return err
+	}
+	if err := r.deleteObsoleteTeams(ctx, ctfdClient, ctfdTeams, statusBefore.Teams, ctfd); err != nil {
+		return err
+	}
+
+	if !equality.Semantic.DeepEqual(statusBefore.Teams, ctfd.Status.Teams) ||
+		!equality.Semantic.DeepEqual(statusBefore.Participants, ctfd.Status.Participants) {
+		if err := r.GetClient().Status().Update(ctx, ctfd); err != nil {
+			return err
+		}
+	}
+	return membershipErr
+}
+
+// updateRosterStatus reflects the bookkeeping of the CTFd instance in the status of the Teams and Participants. The
+// status is only written when it changed.
+func (r *RosterReconciler) updateRosterStatus(ctx context.Context, ctfd *v1alpha1.CTFd, k8sTeams []v1alpha1.Team, k8sParticipants []v1alpha1.Participant, rosterErr error) error {
+	for i := range k8sTeams {
+		k8sTeam := &k8sTeams[i]
+		statusBefore := k8sTeam.Status.DeepCopy()
+		k8sTeam.Status.ObservedGeneration = k8sTeam.Generation
+		k8sTeam.Status.Id = 0
+		if teamStatusIndex := r.getTeamStatusIndex(ctfd.Status.Teams, k8sTeam.Name); teamStatusIndex != -1 {
+			k8sTeam.Status.Id = ctfd.Status.Teams[teamStatusIndex].Id
+		}
+		meta.SetStatusCondition(&k8sTeam.Status.Conditions, r.getSyncedCondition(k8sTeam, k8sTeam.Status.Id, rosterErr))
+		if equality.Semantic.DeepEqual(statusBefore, &k8sTeam.Status) {
+			continue
+		}
+		if err := r.GetClient().Status().Update(ctx, k8sTeam); err != nil {
+			return err
+		}
+	}
+
+	for i := range k8sParticipants {
+		k8sParticipant := &k8sParticipants[i]
+		statusBefore := k8sParticipant.Status.DeepCopy()
+		k8sParticipant.Status.ObservedGeneration = k8sParticipant.Generation
+		k8sParticipant.Status.Id = 0
+		if participantStatusIndex := r.getParticipantStatusIndex(ctfd.Status.Participants, k8sParticipant.Name); participantStatusIndex != -1 {
+			k8sParticipant.Status.Id = ctfd.Status.Participants[participantStatusIndex].Id
+		}
+		condition := r.getSyncedCondition(k8sParticipant, k8sParticipant.Status.Id, rosterErr)
+		if len(k8sParticipant.Spec.TeamName) != 0 && r.getTeamStatusIndex(ctfd.Status.Teams, k8sParticipant.Spec.TeamName) == -1 {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = "TeamNotFound"
+			condition.Message = "The team of the participant does not exist in CTFd."
+		}
+		meta.SetStatusCondition(&k8sParticipant.Status.Conditions, condition)
+		if equality.Semantic.DeepEqual(statusBefore, &k8sParticipant.Status) {
+			continue
+		}
+		if err := r.GetClient().Status().Update(ctx, k8sParticipant); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getSyncedCondition returns the Synced condition for a Team or Participant with the given id in CTFd. The roster
+// error can not be attributed to individual resources, so it is reported on all of them.
+func (r *RosterReconciler) getSyncedCondition(obj client.Object, id int, rosterErr error) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               v1alpha1.ConditionTypeSynced,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: obj.GetGeneration(),
+		Reason:             "Synced",
+		Message:            "The resource is synced into CTFd.",
+	}
+	switch {
+	case rosterErr != nil:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "RosterSyncFailed"
+		condition.Message = "Failed to sync teams and participants, check the RosterSyncFailed events of the CTFd instance for details."
+	case id == 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "NotCreated"
+		condition.Message = "The resource was not created in CTFd yet."
+	}
+	return condition
+}
+
+func (r *RosterReconciler) randomString(length int) (string, error) {
+	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	result := make([]byte, length)
+	for i := range result {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+		if err != nil {
+			return "", err
+		}
+		result[i] = charset[n.Int64()]
+	}
+	return string(result), nil
+}
+
+func (r *RosterReconciler) createRandomPassword() (string, error) {
+	return r.randomString(16)
+}
+
+func (r *RosterReconciler) SetCTFdEndpoint(endpoint CTFdEndpointStrategy) {
+	r.ctfdEndpoint = endpoint
+}
@@ -0,0 +1,277 @@
+package ctfd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/backbone81/ctf-ui-operator/api/v1alpha1"
+	"github.com/backbone81/ctf-ui-operator/internal/ctfdapi"
+)
+
+// listK8sParticipants returns all Participants which reference the given CTFd instance and which are not being
+// deleted.
+func (r *RosterReconciler) listK8sParticipants(ctx context.Context, ctfd *v1alpha1.CTFd) ([]v1alpha1.Participant, error) {
+	var participantList v1alpha1.ParticipantList
+	if err := r.GetClient().List(ctx, &participantList, client.InNamespace(ctfd.Namespace)); err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(participantList.Items, func(k8sParticipant v1alpha1.Participant) bool {
+		return k8sParticipant.Spec.CTFdName != ctfd.Name || !k8sParticipant.DeletionTimestamp.IsZero()
+	}), nil
+}
+
+func (r *RosterReconciler) cleanupParticipantStatus(ctfd *v1alpha1.CTFd, k8sParticipants []v1alpha1.Participant, ctfdUsers []ctfdapi.User) {
+	// Remove participants from the bookkeeping which can not be found in Kubernetes anymore.
+	ctfd.Status.Participants = slices.DeleteFunc(ctfd.Status.Participants, func(participantStatus v1alpha1.CTFdParticipantStatus) bool {
+		return r.getK8sParticipantIndex(k8sParticipants, participantStatus.Name) == -1
+	})
+
+	// Remove participants from the bookkeeping which can not be found in CTFd anymore.
+	ctfd.Status.Participants = slices.DeleteFunc(ctfd.Status.Participants, func(participantStatus v1alpha1.CTFdParticipantStatus) bool {
+		return r.getCTFdUserIndex(ctfdUsers, participantStatus.Id) == -1
+	})
+}
+
+func (r *RosterReconciler) getK8sParticipantIndex(k8sParticipants []v1alpha1.Participant, name string) int {
+	return slices.IndexFunc(k8sParticipants, func(k8sParticipant v1alpha1.Participant) bool {
+		return k8sParticipant.Name == name
+	})
+}
+
+func (r *RosterReconciler) getCTFdUserIndex(ctfdUsers []ctfdapi.User, id int) int {
+	return slices.IndexFunc(ctfdUsers, func(ctfdUser ctfdapi.User) bool {
+		return ctfdUser.Id == id
+	})
+}
+
+func (r *RosterReconciler) getParticipantStatusIndex(participantStatus []v1alpha1.CTFdParticipantStatus, name string) int {
+	return slices.IndexFunc(participantStatus, func(participantStatus v1alpha1.CTFdParticipantStatus) bool {
+		return participantStatus.Name == name
+	})
+}
+
+func (r *RosterReconciler) updateExistingParticipants(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdUsers []ctfdapi.User, ctfd *v1alpha1.CTFd, k8sParticipants []v1alpha1.Participant) error {
+	for _, participantStatus := range ctfd.Status.Participants {
+		ctfdUser := ctfdUsers[r.getCTFdUserIndex(ctfdUsers, participantStatus.Id)]
+		k8sParticipant := k8sParticipants[r.getK8sParticipantIndex(k8sParticipants, participantStatus.Name)]
+		if _, err := r.reconcileParticipantSecret(ctx, ctfd, &k8sParticipant); err != nil {
+			return err
+		}
+		if r.isSameParticipant(ctfdUser, k8sParticipant) {
+			continue
+		}
+
+		ctrl.LoggerFrom(ctx).Info(
+			"Updating participant",
+			"id", ctfdUser.Id,
+			"name", k8sParticipant.Spec.Name,
+		)
+		r.applyDesiredParticipant(&ctfdUser, k8sParticipant)
+		if _, err := ctfdClient.UpdateUser(ctx, ctfdUser); err != nil {
+			return err
+		}
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "ParticipantUpdated", "Updated participant %q with id %d", ctfdUser.Name, ctfdUser.Id)
+	}
+	return nil
+}
+
+func (r *RosterReconciler) isSameParticipant(ctfdUser ctfdapi.User, k8sParticipant v1alpha1.Participant) bool {
+	return ctfdUser.Name == k8sParticipant.Spec.Name &&
+		ctfdUser.Email == k8sParticipant.Spec.Email &&
+		ctfdUser.Hidden == k8sParticipant.Spec.Hidden &&
+		ctfdUser.Banned == k8sParticipant.Spec.Banned &&
+		(k8sParticipant.Spec.BracketId == nil || ctfdUser.BracketId != nil && *ctfdUser.BracketId == *k8sParticipant.Spec.BracketId)
+}
+
+func (r *RosterReconciler) applyDesiredParticipant(ctfdUser *ctfdapi.User, k8sParticipant v1alpha1.Participant) {
+	ctfdUser.Name = k8sParticipant.Spec.Name
+	ctfdUser.Email = k8sParticipant.Spec.Email
+	ctfdUser.Hidden = k8sParticipant.Spec.Hidden
+	ctfdUser.Banned = k8sParticipant.Spec.Banned
+	if k8sParticipant.Spec.BracketId != nil {
+		ctfdUser.BracketId = k8sParticipant.Spec.BracketId
+	}
+}
+
+func (r *RosterReconciler) createMissingParticipants(ctx context.Context, ctfdClient *ctfdapi.Client, ctfd *v1alpha1.CTFd, k8sParticipants []v1alpha1.Participant) error {
+	for _, k8sParticipant := range k8sParticipants {
+		if r.getParticipantStatusIndex(ctfd.Status.Participants, k8sParticipant.Name) != -1 {
+			continue
+		}
+
+		// The secret is created before the user, so that the password is not lost when creating the user fails
+		// half-way.
+		password, err := r.reconcileParticipantSecret(ctx, ctfd, &k8sParticipant)
+		if err != nil {
+			return err
+		}
+		desiredUser := ctfdapi.User{
+			Password: password,
+			// The participant is created by an admin. There is no need for the participant to verify the email
+			// address.
+			Verified: true,
+		}
+		r.applyDesiredParticipant(&desiredUser, k8sParticipant)
+
+		ctrl.LoggerFrom(ctx).Info(
+			"Creating participant",
+			"name", k8sParticipant.Spec.Name,
+		)
+		ctfdUser, err := ctfdClient.CreateUser(ctx, desiredUser, false)
+		if err != nil {
+			return err
+		}
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "ParticipantCreated", "Created participant %q with id %d", ctfdUser.Name, ctfdUser.Id)
+		ctfd.Status.Participants = append(ctfd.Status.Participants, v1alpha1.CTFdParticipantStatus{
+			Id:   ctfdUser.Id,
+			Name: k8sParticipant.Name,
+		})
+		if err := r.GetClient().Status().Update(ctx, ctfd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileMemberships moves all participants into the team they reference. Participants which were created during
+// this reconcile are not part of the given users and are therefore not a member of any team yet.
+func (r *RosterReconciler) reconcileMemberships(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdUsers []ctfdapi.User, ctfd *v1alpha1.CTFd, k8sParticipants []v1alpha1.Participant) error {
+	var result error
+	for _, participantStatus := range ctfd.Status.Participants {
+		k8sParticipant := k8sParticipants[r.getK8sParticipantIndex(k8sParticipants, participantStatus.Name)]
+		var currentTeamId *int
+		if ctfdUserIndex := r.getCTFdUserIndex(ctfdUsers, participantStatus.Id); ctfdUserIndex != -1 {
+			currentTeamId = ctfdUsers[ctfdUserIndex].TeamId
+		}
+
+		var desiredTeamId *int
+		if len(k8sParticipant.Spec.TeamName) != 0 {
+			teamStatusIndex := r.getTeamStatusIndex(ctfd.Status.Teams, k8sParticipant.Spec.TeamName)
+			if teamStatusIndex == -1 {
+				result = errors.Join(result, fmt.Errorf("team %q of participant %q does not exist", k8sParticipant.Spec.TeamName, k8sParticipant.Name))
+				continue
+			}
+			desiredTeamId = &ctfd.Status.Teams[teamStatusIndex].Id
+		}
+
+		if currentTeamId != nil && (desiredTeamId == nil || *currentTeamId != *desiredTeamId) {
+			ctrl.LoggerFrom(ctx).Info(
+				"Removing participant from team",
+				"id", participantStatus.Id,
+				"team-id", *currentTeamId,
+			)
+			if _, err := ctfdClient.RemoveTeamMember(ctx, *currentTeamId, participantStatus.Id); err != nil {
+				return err
+			}
+			r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "TeamMemberRemoved", "Removed participant %q from team with id %d", k8sParticipant.Spec.Name, *currentTeamId)
+		}
+		if desiredTeamId != nil && (currentTeamId == nil || *currentTeamId != *desiredTeamId) {
+			ctrl.LoggerFrom(ctx).Info(
+				"Adding participant to team",
+				"id", participantStatus.Id,
+				"team-id", *desiredTeamId,
+			)
+			if _, err := ctfdClient.AddTeamMember(ctx, *desiredTeamId, participantStatus.Id); err != nil {
+				return err
+			}
+			r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "TeamMemberAdded", "Added participant %q to team with id %d", k8sParticipant.Spec.Name, *desiredTeamId)
+		}
+	}
+	return result
+}
+
+// deleteObsoleteParticipants deletes all users which are not part of the bookkeeping anymore. Users which were never
+// part of the bookkeeping were not created by the operator and are left alone.
+func (r *RosterReconciler) deleteObsoleteParticipants(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdUsers []ctfdapi.User, participantStatusBefore []v1alpha1.CTFdParticipantStatus, ctfd *v1alpha1.CTFd) error {
+	for _, participantStatus := range participantStatusBefore {
+		if slices.Contains(ctfd.Status.Participants, participantStatus) {
+			continue
+		}
+		ctfdUserIndex := r.getCTFdUserIndex(ctfdUsers, participantStatus.Id)
+		if ctfdUserIndex == -1 {
+			// The user was already deleted in CTFd.
+			continue
+		}
+		ctfdUser := ctfdUsers[ctfdUserIndex]
+		ctrl.LoggerFrom(ctx).Info(
+			"Deleting participant",
+			"id", ctfdUser.Id,
+			"name", ctfdUser.Name,
+		)
+		if err := ctfdapi.IgnoreNotFound(ctfdClient.DeleteUser(ctx, ctfdUser.Id)); err != nil {
+			return err
+		}
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "ParticipantDeleted", "Deleted participant %q with id %d", ctfdUser.Name, ctfdUser.Id)
+	}
+	return nil
+}
+
+// reconcileParticipantSecret makes sure that the secret with the credentials of the participant exists and returns
+// the password. The password is only generated once. Changing the password in CTFd does not update the secret, as
+// CTFd never returns the password.
+func (r *RosterReconciler) reconcileParticipantSecret(ctx context.Context, ctfd *v1alpha1.CTFd, k8sParticipant *v1alpha1.Participant) (string, error) {
+	var secret corev1.Secret
+	if err := r.GetClient().Get(ctx, client.ObjectKey{
+		Name:      ParticipantSecretName(k8sParticipant),
+		Namespace: k8sParticipant.Namespace,
+	}, &secret); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return "", err
+		}
+		return r.createParticipantSecret(ctx, ctfd, k8sParticipant)
+	}
+
+	if len(secret.Data["password"]) == 0 {
+		return "", errors.New("password is empty in participant secret")
+	}
+	if string(secret.Data["name"]) == k8sParticipant.Spec.Name && string(secret.Data["email"]) == k8sParticipant.Spec.Email {
+		return string(secret.Data["password"]), nil
+	}
+	secret.Data["name"] = []byte(k8sParticipant.Spec.Name)
+	secret.Data["email"] = []byte(k8sParticipant.Spec.Email)
+	if err := r.GetClient().Update(ctx, &secret); err != nil {
+		return "", err
+	}
+	return string(secret.Data["password"]), nil
+}
+
+func (r *RosterReconciler) createParticipantSecret(ctx context.Context, ctfd *v1alpha1.CTFd, k8sParticipant *v1alpha1.Participant) (string, error) {
+	password, err := r.createRandomPassword()
+	if err != nil {
+		return "", err
+	}
+	secret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ParticipantSecretName(k8sParticipant),
+			Namespace: k8sParticipant.Namespace,
+			Labels:    ctfd.GetDesiredLabels(),
+		},
+		StringData: map[string]string{
+			"name":     k8sParticipant.Spec.Name,
+			"email":    k8sParticipant.Spec.Email,
+			"password": password,
+		},
+	}
+	// The secret belongs to the participant. It is garbage collected together with the participant.
+	if err := controllerutil.SetControllerReference(k8sParticipant, &secret, r.GetClient().Scheme()); err != nil {
+		return "", err
+	}
+	if err := r.GetClient().Create(ctx, &secret); err != nil {
+		return "", err
+	}
+	r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "ParticipantSecretCreated", "Created secret %q with the credentials of participant %q", secret.Name, k8sParticipant.Spec.Name)
+	return password, nil
+}
+
+// ParticipantSecretName returns the name of the secret which provides the credentials of the given participant.
+func ParticipantSecretName(participant *v1alpha1.Participant) string {
+	return participant.Name + "-credentials"
+}
@@ -0,0 +1,190 @@
+package ctfd
+
+import (
+	"context"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/backbone81/ctf-ui-operator/api/v1alpha1"
+	"github.com/backbone81/ctf-ui-operator/internal/ctfdapi"
+)
+
+// listK8sTeams returns all Teams which reference the given CTFd instance and which are not being deleted.
+func (r *RosterReconciler) listK8sTeams(ctx context.Context, ctfd *v1alpha1.CTFd) ([]v1alpha1.Team, error) {
+	var teamList v1alpha1.TeamList
+	if err := r.GetClient().List(ctx, &teamList, client.InNamespace(ctfd.Namespace)); err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(teamList.Items, func(k8sTeam v1alpha1.Team) bool {
+		return k8sTeam.Spec.CTFdName != ctfd.Name || !k8sTeam.DeletionTimestamp.IsZero()
+	}), nil
+}
+
+func (r *RosterReconciler) cleanupTeamStatus(ctfd *v1alpha1.CTFd, k8sTeams []v1alpha1.Team, ctfdTeams []ctfdapi.Team) {
+	// Remove teams from the bookkeeping which can not be found in Kubernetes anymore.
+	ctfd.Status.Teams = slices.DeleteFunc(ctfd.Status.Teams, func(teamStatus v1alpha1.CTFdTeamStatus) bool {
+		return r.getK8sTeamIndex(k8sTeams, teamStatus.Name) == -1
+	})
+
+	// Remove teams from the bookkeeping which can not be found in CTFd anymore.
+	ctfd.Status.Teams = slices.DeleteFunc(ctfd.Status.Teams, func(teamStatus v1alpha1.CTFdTeamStatus) bool {
+		return r.getCTFdTeamIndex(ctfdTeams, teamStatus.Id) == -1
+	})
+}
+
+func (r *RosterReconciler) getK8sTeamIndex(k8sTeams []v1alpha1.Team, name string) int {
+	return slices.IndexFunc(k8sTeams, func(k8sTeam v1alpha1.Team) bool {
+		return k8sTeam.Name == name
+	})
+}
+
+func (r *RosterReconciler) getCTFdTeamIndex(ctfdTeams []ctfdapi.Team, id int) int {
+	return slices.IndexFunc(ctfdTeams, func(ctfdTeam ctfdapi.Team) bool {
+		return ctfdTeam.Id == id
+	})
+}
+
+func (r *RosterReconciler) getTeamStatusIndex(teamStatus []v1alpha1.CTFdTeamStatus, name string) int {
+	return slices.IndexFunc(teamStatus, func(teamStatus v1alpha1.CTFdTeamStatus) bool {
+		return teamStatus.Name == name
+	})
+}
+
+func (r *RosterReconciler) updateExistingTeams(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdTeams []ctfdapi.Team, ctfd *v1alpha1.CTFd, k8sTeams []v1alpha1.Team) error {
+	for _, teamStatus := range ctfd.Status.Teams {
+		ctfdTeam := ctfdTeams[r.getCTFdTeamIndex(ctfdTeams, teamStatus.Id)]
+		k8sTeam := k8sTeams[r.getK8sTeamIndex(k8sTeams, teamStatus.Name)]
+		if r.isSameTeam(ctfdTeam, k8sTeam) {
+			continue
+		}
+
+		ctrl.LoggerFrom(ctx).Info(
+			"Updating team",
+			"id", ctfdTeam.Id,
+			"name", k8sTeam.Spec.Name,
+		)
+		r.applyDesiredTeam(&ctfdTeam, k8sTeam)
+		if _, err := ctfdClient.UpdateTeam(ctx, ctfdTeam); err != nil {
+			return err
+		}
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "TeamUpdated", "Updated team %q with id %d", ctfdTeam.Name, ctfdTeam.Id)
+	}
+	return nil
+}
+
+func (r *RosterReconciler) isSameTeam(ctfdTeam ctfdapi.Team, k8sTeam v1alpha1.Team) bool {
+	return ctfdTeam.Name == k8sTeam.Spec.Name &&
+		ctfdTeam.Email == k8sTeam.Spec.Email &&
+		ctfdTeam.Hidden == k8sTeam.Spec.Hidden &&
+		ctfdTeam.Banned == k8sTeam.Spec.Banned &&
+		(k8sTeam.Spec.BracketId == nil || ctfdTeam.BracketId != nil && *ctfdTeam.BracketId == *k8sTeam.Spec.BracketId)
+}
+
+func (r *RosterReconciler) applyDesiredTeam(ctfdTeam *ctfdapi.Team, k8sTeam v1alpha1.Team) {
+	ctfdTeam.Name = k8sTeam.Spec.Name
+	ctfdTeam.Email = k8sTeam.Spec.Email
+	ctfdTeam.Hidden = k8sTeam.Spec.Hidden
+	ctfdTeam.Banned = k8sTeam.Spec.Banned
+	if k8sTeam.Spec.BracketId != nil {
+		ctfdTeam.BracketId = k8sTeam.Spec.BracketId
+	}
+}
+
+func (r *RosterReconciler) createMissingTeams(ctx context.Context, ctfdClient *ctfdapi.Client, ctfd *v1alpha1.CTFd, k8sTeams []v1alpha1.Team) error {
+	for _, k8sTeam := range k8sTeams {
+		if r.getTeamStatusIndex(ctfd.Status.Teams, k8sTeam.Name) != -1 {
+			continue
+		}
+
+		// The team password is only needed for joining a team through CTFd. Team membership is managed through the
+		// Participants, so we set a random password nobody knows.
+		password, err := r.createRandomPassword()
+		if err != nil {
+			return err
+		}
+		desiredTeam := ctfdapi.Team{
+			Password: password,
+		}
+		r.applyDesiredTeam(&desiredTeam, k8sTeam)
+
+		ctrl.LoggerFrom(ctx).Info(
+			"Creating team",
+			"name", k8sTeam.Spec.Name,
+		)
+		ctfdTeam, err := ctfdClient.CreateTeam(ctx, desiredTeam)
+		if err != nil {
+			return err
+		}
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "TeamCreated", "Created team %q with id %d", ctfdTeam.Name, ctfdTeam.Id)
+		ctfd.Status.Teams = append(ctfd.Status.Teams, v1alpha1.CTFdTeamStatus{
+			Id:   ctfdTeam.Id,
+			Name: k8sTeam.Name,
+		})
+		if err := r.GetClient().Status().Update(ctx, ctfd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileTeamCaptains makes sure that every team with members has a captain. Only the captain is able to manage the
+// team in CTFd. The first member becomes the captain when the team has no captain or the captain left the team.
+func (r *RosterReconciler) reconcileTeamCaptains(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdTeams []ctfdapi.Team, ctfd *v1alpha1.CTFd) error {
+	for _, teamStatus := range ctfd.Status.Teams {
+		members, err := ctfdClient.ListTeamMembers(ctx, teamStatus.Id)
+		if err != nil {
+			return err
+		}
+		if len(members) == 0 {
+			continue
+		}
+		// Teams which were just created are not part of the list and therefore have no captain yet.
+		if ctfdTeamIndex := r.getCTFdTeamIndex(ctfdTeams, teamStatus.Id); ctfdTeamIndex != -1 {
+			captainId := ctfdTeams[ctfdTeamIndex].CaptainId
+			if captainId != nil && slices.Contains(members, *captainId) {
+				continue
+			}
+		}
+
+		ctrl.LoggerFrom(ctx).Info(
+			"Assigning team captain",
+			"id", teamStatus.Id,
+			"user-id", members[0],
+		)
+		ctfdTeam, err := ctfdClient.SetTeamCaptain(ctx, teamStatus.Id, members[0])
+		if err != nil {
+			return err
+		}
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "TeamCaptainAssigned", "Assigned user with id %d as captain of team %q", members[0], ctfdTeam.Name)
+	}
+	return nil
+}
+
+// deleteObsoleteTeams deletes all teams which are not part of the bookkeeping anymore. Teams which were never part of
+// the bookkeeping were not created by the operator and are left alone.
+func (r *RosterReconciler) deleteObsoleteTeams(ctx context.Context, ctfdClient *ctfdapi.Client, ctfdTeams []ctfdapi.Team, teamStatusBefore []v1alpha1.CTFdTeamStatus, ctfd *v1alpha1.CTFd) error {
+	for _, teamStatus := range teamStatusBefore {
+		if slices.Contains(ctfd.Status.Teams, teamStatus) {
+			continue
+		}
+		ctfdTeamIndex := r.getCTFdTeamIndex(ctfdTeams, teamStatus.Id)
+		if ctfdTeamIndex == -1 {
+			// The team was already deleted in CTFd.
+			continue
+		}
+		ctfdTeam := ctfdTeams[ctfdTeamIndex]
+		ctrl.LoggerFrom(ctx).Info(
+			"Deleting team",
+			"id", ctfdTeam.Id,
+			"name", ctfdTeam.Name,
+		)
+		if err := ctfdapi.IgnoreNotFound(ctfdClient.DeleteTeam(ctx, ctfdTeam.Id)); err != nil {
+			return err
+		}
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeNormal, "TeamDeleted", "Deleted team %q with id %d", ctfdTeam.Name, ctfdTeam.Id)
+	}
+	return nil
+}
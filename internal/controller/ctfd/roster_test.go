@@ -0,0 +1,237 @@
+package ctfd_test
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/backbone81/ctf-ui-operator/api/v1alpha1"
+	"github.com/backbone81/ctf-ui-operator/internal/controller/ctfd"
+	"github.com/backbone81/ctf-ui-operator/internal/ctfdapi"
+	"github.com/backbone81/ctf-ui-operator/internal/testutils"
+	"github.com/backbone81/ctf-ui-operator/internal/utils"
+)
+
+var _ = Describe("RosterReconciler", func() {
+	var (
+		reconciler *utils.Reconciler[*v1alpha1.CTFd]
+		ctfdClient *ctfdapi.Client
+	)
+
+	BeforeEach(func() {
+		reconciler = ctfd.NewReconciler(k8sClient, recorder, ctfd.WithRosterReconciler(WithCTFdTestEndpoint(endpointUrl)))
+		var err error
+		ctfdClient, err = ctfdapi.NewClient(endpointUrl, accessToken)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func(ctx SpecContext) {
+		Expect(k8sClient.DeleteAllOf(ctx, &v1alpha1.Participant{}, client.InNamespace(corev1.NamespaceDefault))).To(Succeed())
+		Expect(k8sClient.DeleteAllOf(ctx, &v1alpha1.Team{}, client.InNamespace(corev1.NamespaceDefault))).To(Succeed())
+		DeleteAllInstances(ctx)
+		users, err := ctfdClient.ListUsers(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		for _, user := range users {
+			if user.Type == ctfdapi.UserTypeAdmin {
+				continue
+			}
+			Expect(ctfdClient.DeleteUser(ctx, user.Id)).To(Succeed())
+		}
+		teams, err := ctfdClient.ListTeams(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		for _, team := range teams {
+			Expect(ctfdClient.DeleteTeam(ctx, team.Id)).To(Succeed())
+		}
+	})
+
+	It("should create teams and participants", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		team := v1alpha1.Team{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.TeamSpec{
+				CTFdName: instance.Name,
+				Name:     "Red Team",
+			},
+		}
+		Expect(k8sClient.Create(ctx, &team)).To(Succeed())
+		participant := v1alpha1.Participant{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.ParticipantSpec{
+				CTFdName: instance.Name,
+				Name:     "alice",
+				Email:    "alice@ctfd.internal",
+				TeamName: team.Name,
+			},
+		}
+		Expect(k8sClient.Create(ctx, &participant)).To(Succeed())
+
+		By("run the reconciler")
+		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		By("verify all postconditions")
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		Expect(instance.Status.Teams).To(HaveLen(1))
+		Expect(instance.Status.Participants).To(HaveLen(1))
+		Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, v1alpha1.ConditionTypeRosterSynced)).To(BeTrue())
+
+		user, err := ctfdClient.GetUser(ctx, instance.Status.Participants[0].Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(user.Name).To(Equal("alice"))
+		Expect(user.TeamId).To(HaveValue(Equal(instance.Status.Teams[0].Id)))
+		ctfdTeam, err := ctfdClient.GetTeam(ctx, instance.Status.Teams[0].Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ctfdTeam.Name).To(Equal("Red Team"))
+		Expect(ctfdTeam.CaptainId).To(HaveValue(Equal(user.Id)))
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&team), &team)).To(Succeed())
+		Expect(team.Status.Id).To(Equal(ctfdTeam.Id))
+		Expect(team.Status.ObservedGeneration).To(Equal(team.Generation))
+		Expect(meta.IsStatusConditionTrue(team.Status.Conditions, v1alpha1.ConditionTypeSynced)).To(BeTrue())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&participant), &participant)).To(Succeed())
+		Expect(participant.Status.Id).To(Equal(user.Id))
+		Expect(participant.Status.ObservedGeneration).To(Equal(participant.Generation))
+		Expect(meta.IsStatusConditionTrue(participant.Status.Conditions, v1alpha1.ConditionTypeSynced)).To(BeTrue())
+
+		var secret corev1.Secret
+		Expect(k8sClient.Get(ctx, client.ObjectKey{
+			Name:      ctfd.ParticipantSecretName(&participant),
+			Namespace: participant.Namespace,
+		}, &secret)).To(Succeed())
+		Expect(secret.Data["password"]).ToNot(BeEmpty())
+		Expect(secret.OwnerReferences).To(ContainElement(HaveField("UID", participant.UID)))
+
+		events := RecordedEvents()
+		Expect(events).To(ContainElement(HavePrefix("Normal TeamCreated")))
+		Expect(events).To(ContainElement(HavePrefix("Normal ParticipantCreated")))
+		Expect(events).To(ContainElement(HavePrefix("Normal TeamMemberAdded")))
+	})
+
+	It("should update and delete participants", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		bannedParticipant := v1alpha1.Participant{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.ParticipantSpec{
+				CTFdName: instance.Name,
+				Name:     "mallory",
+				Email:    "mallory@ctfd.internal",
+			},
+		}
+		Expect(k8sClient.Create(ctx, &bannedParticipant)).To(Succeed())
+		deletedParticipant := v1alpha1.Participant{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.ParticipantSpec{
+				CTFdName: instance.Name,
+				Name:     "bob",
+				Email:    "bob@ctfd.internal",
+			},
+		}
+		Expect(k8sClient.Create(ctx, &deletedParticipant)).To(Succeed())
+		Expect(reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))).Error().ToNot(HaveOccurred())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		Expect(instance.Status.Participants).To(HaveLen(2))
+		usersBefore, err := ctfdClient.ListUsers(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&bannedParticipant), &bannedParticipant)).To(Succeed())
+		bannedParticipant.Spec.Banned = true
+		Expect(k8sClient.Update(ctx, &bannedParticipant)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, &deletedParticipant)).To(Succeed())
+
+		By("run the reconciler")
+		result, err := reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeZero())
+
+		By("verify all postconditions")
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		Expect(instance.Status.Participants).To(ConsistOf(HaveField("Name", bannedParticipant.Name)))
+		user, err := ctfdClient.GetUser(ctx, instance.Status.Participants[0].Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(user.Banned).To(BeTrue())
+		usersAfter, err := ctfdClient.ListUsers(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(usersAfter).To(HaveLen(len(usersBefore) - 1))
+
+		events := RecordedEvents()
+		Expect(events).To(ContainElement(HavePrefix("Normal ParticipantUpdated")))
+		Expect(events).To(ContainElement(HavePrefix("Normal ParticipantDeleted")))
+	})
+
+	It("should report participants of missing teams", func(ctx SpecContext) {
+		By("prepare test with all preconditions")
+		instance := AddDefaults(v1alpha1.CTFd{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+		})
+		Expect(k8sClient.Create(ctx, &instance)).To(Succeed())
+		instance.Status.Ready = true
+		Expect(k8sClient.Status().Update(ctx, &instance)).To(Succeed())
+		Expect(CreateAdminSecret(ctx, &instance, &accessToken)).To(Succeed())
+		participant := v1alpha1.Participant{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    corev1.NamespaceDefault,
+			},
+			Spec: v1alpha1.ParticipantSpec{
+				CTFdName: instance.Name,
+				Name:     "carol",
+				Email:    "carol@ctfd.internal",
+				TeamName: "does-not-exist",
+			},
+		}
+		Expect(k8sClient.Create(ctx, &participant)).To(Succeed())
+
+		By("run the reconciler")
+		Expect(reconciler.Reconcile(ctx, testutils.RequestFromObject(&instance))).Error().To(HaveOccurred())
+
+		By("verify all postconditions")
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		// The participant is created nevertheless, only the team membership is missing.
+		Expect(instance.Status.Participants).To(HaveLen(1))
+		Expect(meta.IsStatusConditionFalse(instance.Status.Conditions, v1alpha1.ConditionTypeRosterSynced)).To(BeTrue())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&participant), &participant)).To(Succeed())
+		Expect(participant.Status.Id).To(Equal(instance.Status.Participants[0].Id))
+		Expect(meta.FindStatusCondition(participant.Status.Conditions, v1alpha1.ConditionTypeSynced)).To(HaveField("Reason", "TeamNotFound"))
+		Expect(RecordedEvents()).To(ContainElement(HavePrefix("Warning RosterSyncFailed")))
+	})
+})
@@ -9,6 +9,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -24,9 +25,9 @@ type SecretReconciler struct {
 	utils.DefaultSubReconciler
 }
 
-func NewSecretReconciler(client client.Client) *SecretReconciler {
+func NewSecretReconciler(client client.Client, recorder record.EventRecorder) *SecretReconciler {
 	return &SecretReconciler{
-		DefaultSubReconciler: utils.NewDefaultSubReconciler(client),
+		DefaultSubReconciler: utils.NewDefaultSubReconciler(client, recorder),
 	}
 }
 
@@ -18,7 +18,7 @@ var _ = Describe("SecretReconciler", func() {
 	var reconciler *utils.Reconciler[*v1alpha1.CTFd]
 
 	BeforeEach(func() {
-		reconciler = ctfd.NewReconciler(k8sClient, ctfd.WithSecretReconciler())
+		reconciler = ctfd.NewReconciler(k8sClient, recorder, ctfd.WithSecretReconciler())
 	})
 
 	AfterEach(func(ctx SpecContext) {
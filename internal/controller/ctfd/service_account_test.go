@@ -18,7 +18,7 @@ var _ = Describe("ServiceAccountReconciler", func() {
 	var reconciler *utils.Reconciler[*v1alpha1.CTFd]
 
 	BeforeEach(func() {
-		reconciler = ctfd.NewReconciler(k8sClient, ctfd.WithServiceAccountReconciler())
+		reconciler = ctfd.NewReconciler(k8sClient, recorder, ctfd.WithServiceAccountReconciler())
 	})
 
 	AfterEach(func(ctx SpecContext) {
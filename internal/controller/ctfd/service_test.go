@@ -18,7 +18,7 @@ var _ = Describe("ServiceReconciler", func() {
 	var reconciler *utils.Reconciler[*v1alpha1.CTFd]
 
 	BeforeEach(func() {
-		reconciler = ctfd.NewReconciler(k8sClient, ctfd.WithServiceReconciler())
+		reconciler = ctfd.NewReconciler(k8sClient, recorder, ctfd.WithServiceReconciler())
 	})
 
 	AfterEach(func(ctx SpecContext) {
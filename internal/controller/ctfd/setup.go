@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -19,9 +22,9 @@ type SetupReconciler struct {
 	ctfdEndpoint CTFdEndpointStrategy
 }
 
-func NewSetupReconciler(client client.Client, options ...SubReconcilerOption) *SetupReconciler {
+func NewSetupReconciler(client client.Client, recorder record.EventRecorder, options ...SubReconcilerOption) *SetupReconciler {
 	result := &SetupReconciler{
-		DefaultSubReconciler: utils.NewDefaultSubReconciler(client),
+		DefaultSubReconciler: utils.NewDefaultSubReconciler(client, recorder),
 	}
 	for _, option := range options {
 		option(result)
@@ -58,29 +61,47 @@ func (r *SetupReconciler) Reconcile(ctx context.Context, ctfd *v1alpha1.CTFd) (c
 	if !setupRequired {
 		// The setup was already done. No need to do anything here.
 		ctrl.LoggerFrom(ctx).V(1).Info("CTFd is already setup, skipping SetupReconciler.")
-		return ctrl.Result{}, nil
+		return ctrl.Result{}, r.setSetupCompleteCondition(ctx, ctfd, nil)
 	}
 
 	ctrl.LoggerFrom(ctx).Info("Setting up CTFd")
+	if err := r.setup(ctx, ctfdClient, ctfd); err != nil {
+		r.GetRecorder().Eventf(ctfd, corev1.EventTypeWarning, "SetupFailed", "Failed to set up CTFd: %s", err)
+		return ctrl.Result{}, errors.Join(err, r.setSetupCompleteCondition(ctx, ctfd, err))
+	}
+	r.GetRecorder().Event(ctfd, corev1.EventTypeNormal, "SetupDone", "Set up CTFd")
+	return ctrl.Result{}, r.setSetupCompleteCondition(ctx, ctfd, nil)
+}
+
+func (r *SetupReconciler) setup(ctx context.Context, ctfdClient *ctfdapi.Client, ctfd *v1alpha1.CTFd) error {
 	setupRequest, err := r.getSetupRequest(ctx, ctfd)
 	if err != nil {
-		return ctrl.Result{}, err
+		return err
 	}
 	if err := ctfdClient.Setup(ctx, setupRequest); err != nil {
-		return ctrl.Result{}, err
+		return err
 	}
 
 	// We do not get any good feedback when things go wrong during setup. It is basically an HTTP 200 OK but with a
 	// box on the website containing the error message. To be sure that we did in fact successfully set up the instance,
 	// we double-check again if the /setup route is still available.
-	setupRequired, err = ctfdClient.SetupRequired(ctx)
+	setupRequired, err := ctfdClient.SetupRequired(ctx)
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("checking if setup is required: %w", err)
+		return fmt.Errorf("checking if setup is required: %w", err)
 	}
 	if setupRequired {
-		return ctrl.Result{}, errors.New("setup failed: manually check the setup process and make sure that given field values satisfy form validation")
+		return errors.New("setup failed: manually check the setup process and make sure that given field values satisfy form validation")
+	}
+	return nil
+}
+
+// setSetupCompleteCondition reports the outcome of the setup. A nil error means that the setup is complete. The error
+// itself is reported through an event, as the condition message must not change on every failed attempt.
+func (r *SetupReconciler) setSetupCompleteCondition(ctx context.Context, ctfd *v1alpha1.CTFd, setupErr error) error {
+	if setupErr != nil {
+		return r.SetCondition(ctx, ctfd, v1alpha1.ConditionTypeSetupComplete, metav1.ConditionFalse, "SetupFailed", "The initial setup of CTFd failed, check the SetupFailed events for details.")
 	}
-	return ctrl.Result{}, nil
+	return r.SetCondition(ctx, ctfd, v1alpha1.ConditionTypeSetupComplete, metav1.ConditionTrue, "SetupDone", "The initial setup of CTFd is done.")
 }
 
 func (r *SetupReconciler) getEndpoint(ctx context.Context, ctfd *v1alpha1.CTFd) (string, error) {
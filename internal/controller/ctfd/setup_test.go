@@ -4,6 +4,7 @@ import (
 	"github.com/testcontainers/testcontainers-go"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -32,7 +33,7 @@ var _ = Describe("SetupReconciler", func() {
 		Expect(err).ToNot(HaveOccurred())
 		endpointUrl = "http://" + endpoint
 
-		reconciler = ctfd.NewReconciler(k8sClient, ctfd.WithSetupReconciler(WithCTFdTestEndpoint(endpointUrl)))
+		reconciler = ctfd.NewReconciler(k8sClient, recorder, ctfd.WithSetupReconciler(WithCTFdTestEndpoint(endpointUrl)))
 	})
 
 	AfterEach(func(ctx SpecContext) {
@@ -65,5 +66,12 @@ var _ = Describe("SetupReconciler", func() {
 
 		By("verify all postconditions")
 		Expect(ctfdClient.SetupRequired(ctx)).To(BeFalse())
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
+		Expect(instance.Status.Conditions).To(ContainElement(And(
+			HaveField("Type", v1alpha1.ConditionTypeSetupComplete),
+			HaveField("Status", metav1.ConditionTrue),
+		)))
+		Expect(RecordedEvents()).To(ContainElement(HavePrefix("Normal SetupDone")))
 	})
 })
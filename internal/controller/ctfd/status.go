@@ -2,8 +2,12 @@ package ctfd
 
 import (
 	"context"
+	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -18,9 +22,9 @@ type StatusReconciler struct {
 	utils.DefaultSubReconciler
 }
 
-func NewStatusReconciler(client client.Client) *StatusReconciler {
+func NewStatusReconciler(client client.Client, recorder record.EventRecorder) *StatusReconciler {
 	return &StatusReconciler{
-		DefaultSubReconciler: utils.NewDefaultSubReconciler(client),
+		DefaultSubReconciler: utils.NewDefaultSubReconciler(client, recorder),
 	}
 }
 
@@ -28,7 +32,6 @@ func (r *StatusReconciler) SetupWithManager(ctrlBuilder *builder.Builder) *build
 	return ctrlBuilder.Owns(&appsv1.Deployment{})
 }
 
-//nolint:cyclop // The ready status is dependent on several other resources.
 func (r *StatusReconciler) Reconcile(ctx context.Context, ctfd *v1alpha1.CTFd) (ctrl.Result, error) {
 	if !ctfd.DeletionTimestamp.IsZero() {
 		// We do not update the status when the resource is already being deleted.
@@ -55,23 +58,59 @@ func (r *StatusReconciler) Reconcile(ctx context.Context, ctfd *v1alpha1.CTFd) (
 		return ctrl.Result{}, err
 	}
 
-	ready := redis != nil && redis.Status.Ready &&
-		mariaDB != nil && mariaDB.Status.Ready &&
-		minio != nil && minio.Status.Ready &&
-		deployment != nil &&
-		deployment.Status.ReadyReplicas > 0 &&
-		deployment.Status.Replicas == deployment.Status.ReadyReplicas
-	if ctfd.Status.Ready == ready {
+	dependenciesCondition := r.getDependenciesCondition(ctfd, redis, mariaDB, minio)
+	readyCondition := utils.GetDeploymentCondition(v1alpha1.ConditionTypeReady, ctfd.Generation, deployment)
+	if dependenciesCondition.Status != metav1.ConditionTrue {
+		// CTFd is not usable without its dependencies, even when the deployment reports all replicas as ready.
+		readyCondition.Status = metav1.ConditionFalse
+		readyCondition.Reason = dependenciesCondition.Reason
+		readyCondition.Message = dependenciesCondition.Message
+	}
+	ready := readyCondition.Status == metav1.ConditionTrue
+	conditionChanged := meta.SetStatusCondition(&ctfd.Status.Conditions, dependenciesCondition)
+	conditionChanged = meta.SetStatusCondition(&ctfd.Status.Conditions, readyCondition) || conditionChanged
+	if ctfd.Status.Ready == ready && ctfd.Status.ObservedGeneration == ctfd.Generation && !conditionChanged {
 		return ctrl.Result{}, nil
 	}
 
 	ctfd.Status.Ready = ready
+	ctfd.Status.ObservedGeneration = ctfd.Generation
 	if err := r.GetClient().Status().Update(ctx, ctfd); err != nil {
 		return ctrl.Result{}, err
 	}
 	return ctrl.Result{}, nil
 }
 
+func (r *StatusReconciler) getDependenciesCondition(ctfd *v1alpha1.CTFd, redis *v1alpha1.Redis, mariaDB *v1alpha1.MariaDB, minio *v1alpha1.Minio) metav1.Condition {
+	var notReady []string
+	if redis == nil || !redis.Status.Ready {
+		notReady = append(notReady, "Redis")
+	}
+	if mariaDB == nil || !mariaDB.Status.Ready {
+		notReady = append(notReady, "MariaDB")
+	}
+	if minio == nil || !minio.Status.Ready {
+		notReady = append(notReady, "Minio")
+	}
+
+	if len(notReady) != 0 {
+		return metav1.Condition{
+			Type:               v1alpha1.ConditionTypeDependenciesReady,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: ctfd.Generation,
+			Reason:             "DependenciesNotReady",
+			Message:            "Waiting for " + strings.Join(notReady, ", ") + " to become ready.",
+		}
+	}
+	return metav1.Condition{
+		Type:               v1alpha1.ConditionTypeDependenciesReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: ctfd.Generation,
+		Reason:             "DependenciesReady",
+		Message:            "Redis, MariaDB and Minio are ready.",
+	}
+}
+
 func (r *StatusReconciler) getDeployment(ctx context.Context, ctfd *v1alpha1.CTFd) (*appsv1.Deployment, error) {
 	var deployment appsv1.Deployment
 	if err := r.GetClient().Get(ctx, client.ObjectKeyFromObject(ctfd), &deployment); err != nil {
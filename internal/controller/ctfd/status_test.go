@@ -20,7 +20,7 @@ var _ = Describe("StatusReconciler", func() {
 	var reconciler *utils.Reconciler[*v1alpha1.CTFd]
 
 	BeforeEach(func() {
-		reconciler = ctfd.NewReconciler(k8sClient, ctfd.WithStatusReconciler())
+		reconciler = ctfd.NewReconciler(k8sClient, recorder, ctfd.WithStatusReconciler())
 	})
 
 	AfterEach(func(ctx SpecContext) {
@@ -75,6 +75,11 @@ var _ = Describe("StatusReconciler", func() {
 		By("verify all postconditions")
 		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
 		Expect(instance.Status.Ready).To(BeFalse())
+		Expect(instance.Status.ObservedGeneration).To(Equal(instance.Generation))
+		Expect(instance.Status.Conditions).To(ContainElement(And(
+			HaveField("Type", v1alpha1.ConditionTypeReady),
+			HaveField("Status", metav1.ConditionFalse),
+		)))
 	})
 
 	It("should set the status to ready when all replicas are ready", func(ctx SpecContext) {
@@ -125,5 +130,10 @@ var _ = Describe("StatusReconciler", func() {
 		By("verify all postconditions")
 		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
 		Expect(instance.Status.Ready).To(BeTrue())
+		Expect(instance.Status.ObservedGeneration).To(Equal(instance.Generation))
+		Expect(instance.Status.Conditions).To(ContainElement(And(
+			HaveField("Type", v1alpha1.ConditionTypeReady),
+			HaveField("Status", metav1.ConditionTrue),
+		)))
 	})
 })
@@ -12,6 +12,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 
@@ -33,6 +34,7 @@ const (
 var (
 	testEnv   *envtest.Environment
 	k8sClient client.Client
+	recorder  *record.FakeRecorder
 
 	container   testcontainers.Container
 	endpointUrl string
@@ -72,6 +74,26 @@ var _ = BeforeSuite(func(ctx SpecContext) {
 	accessToken = createTokenResponse.Data.Value
 })
 
+var _ = BeforeEach(func() {
+	// Every test gets a fresh recorder, so that the events of previous tests do not leak into the current test. The
+	// buffer needs to be large enough to not block the reconciler.
+	recorder = record.NewFakeRecorder(1000)
+})
+
+// RecordedEvents returns all events which were recorded since the last call. The events are formatted by the fake
+// recorder as "<type> <reason> <message>".
+func RecordedEvents() []string {
+	var result []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			result = append(result, event)
+		default:
+			return result
+		}
+	}
+}
+
 var _ = AfterSuite(func(ctx SpecContext) {
 	Expect(testEnv.Stop()).To(Succeed())
 	Expect(container.Terminate(ctx)).To(Succeed())
@@ -82,7 +104,10 @@ func DeleteAllInstances(ctx context.Context) {
 	Expect(k8sClient.List(ctx, &mariadbList)).To(Succeed())
 
 	for _, ctfd := range mariadbList.Items {
-		Expect(k8sClient.Delete(ctx, &ctfd)).To(Succeed())
+		// There is no controller running which would remove our finalizers. We need to remove them ourselves.
+		ctfd.Finalizers = nil
+		Expect(k8sClient.Update(ctx, &ctfd)).To(Succeed())
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &ctfd))).To(Succeed())
 	}
 }
 
@@ -91,7 +116,10 @@ func DeleteAllChallengeDescriptions(ctx context.Context) {
 	Expect(k8sClient.List(ctx, &challengeDescriptionList)).To(Succeed())
 
 	for _, challengeDescription := range challengeDescriptionList.Items {
-		Expect(k8sClient.Delete(ctx, &challengeDescription)).To(Succeed())
+		// There is no controller running which would remove our finalizers. We need to remove them ourselves.
+		challengeDescription.Finalizers = nil
+		Expect(k8sClient.Update(ctx, &challengeDescription)).To(Succeed())
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &challengeDescription))).To(Succeed())
 	}
 }
 
@@ -21,7 +21,7 @@ var _ = Describe("DeploymentReconciler", func() {
 	var reconciler *utils.Reconciler[*v1alpha1.MariaDB]
 
 	BeforeEach(func() {
-		reconciler = mariadb.NewReconciler(k8sClient, mariadb.WithDeploymentReconciler())
+		reconciler = mariadb.NewReconciler(k8sClient, recorder, mariadb.WithDeploymentReconciler())
 	})
 
 	AfterEach(func(ctx SpecContext) {
@@ -20,7 +20,7 @@ var _ = Describe("PersistentVolumeClaimReconciler", func() {
 	var reconciler *utils.Reconciler[*v1alpha1.MariaDB]
 
 	BeforeEach(func() {
-		reconciler = mariadb.NewReconciler(k8sClient, mariadb.WithPersistentVolumeClaimReconciler())
+		reconciler = mariadb.NewReconciler(k8sClient, recorder, mariadb.WithPersistentVolumeClaimReconciler())
 	})
 
 	AfterEach(func(ctx SpecContext) {
@@ -17,7 +17,7 @@ var _ = Describe("Reconciler", func() {
 	var reconciler *utils.Reconciler[*v1alpha1.MariaDB]
 
 	BeforeEach(func() {
-		reconciler = mariadb.NewReconciler(k8sClient, mariadb.WithDefaultReconcilers())
+		reconciler = mariadb.NewReconciler(k8sClient, recorder, mariadb.WithDefaultReconcilers())
 	})
 
 	AfterEach(func(ctx SpecContext) {
@@ -18,7 +18,7 @@ var _ = Describe("SecretReconciler", func() {
 	var reconciler *utils.Reconciler[*v1alpha1.MariaDB]
 
 	BeforeEach(func() {
-		reconciler = mariadb.NewReconciler(k8sClient, mariadb.WithSecretReconciler())
+		reconciler = mariadb.NewReconciler(k8sClient, recorder, mariadb.WithSecretReconciler())
 	})
 
 	AfterEach(func(ctx SpecContext) {
@@ -7,6 +7,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -22,9 +23,9 @@ type ServiceReconciler struct {
 	utils.DefaultSubReconciler
 }
 
-func NewServiceReconciler(client client.Client) *ServiceReconciler {
+func NewServiceReconciler(client client.Client, recorder record.EventRecorder) *ServiceReconciler {
 	return &ServiceReconciler{
-		DefaultSubReconciler: utils.NewDefaultSubReconciler(client),
+		DefaultSubReconciler: utils.NewDefaultSubReconciler(client, recorder),
 	}
 }
 
@@ -32,6 +33,11 @@ func (r *ServiceReconciler) SetupWithManager(ctrlBuilder *builder.Builder) *buil
 	return ctrlBuilder.Owns(&corev1.Service{})
 }
 
+func (r *ServiceReconciler) Blocking() bool {
+	// MariaDB does not connect through its own service, so the deployment can be created without it.
+	return false
+}
+
 func (r *ServiceReconciler) Reconcile(ctx context.Context, mariadb *v1alpha1.MariaDB) (ctrl.Result, error) {
 	currentSpec, err := r.getService(ctx, mariadb)
 	if err != nil {
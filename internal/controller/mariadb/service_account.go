@@ -6,6 +6,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -22,9 +23,9 @@ type ServiceAccountReconciler struct {
 	utils.DefaultSubReconciler
 }
 
-func NewServiceAccountReconciler(client client.Client) *ServiceAccountReconciler {
+func NewServiceAccountReconciler(client client.Client, recorder record.EventRecorder) *ServiceAccountReconciler {
 	return &ServiceAccountReconciler{
-		DefaultSubReconciler: utils.NewDefaultSubReconciler(client),
+		DefaultSubReconciler: utils.NewDefaultSubReconciler(client, recorder),
 	}
 }
 
@@ -18,7 +18,7 @@ var _ = Describe("ServiceAccountReconciler", func() {
 	var reconciler *utils.Reconciler[*v1alpha1.MariaDB]
 
 	BeforeEach(func() {
-		reconciler = mariadb.NewReconciler(k8sClient, mariadb.WithServiceAccountReconciler())
+		reconciler = mariadb.NewReconciler(k8sClient, recorder, mariadb.WithServiceAccountReconciler())
 	})
 
 	AfterEach(func(ctx SpecContext) {
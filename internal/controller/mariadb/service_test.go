@@ -18,7 +18,7 @@ var _ = Describe("ServiceReconciler", func() {
 	var reconciler *utils.Reconciler[*v1alpha1.MariaDB]
 
 	BeforeEach(func() {
-		reconciler = mariadb.NewReconciler(k8sClient, mariadb.WithServiceReconciler())
+		reconciler = mariadb.NewReconciler(k8sClient, recorder, mariadb.WithServiceReconciler())
 	})
 
 	AfterEach(func(ctx SpecContext) {
@@ -4,6 +4,9 @@ import (
 	"context"
 
 	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -18,9 +21,9 @@ type StatusReconciler struct {
 	utils.DefaultSubReconciler
 }
 
-func NewStatusReconciler(client client.Client) *StatusReconciler {
+func NewStatusReconciler(client client.Client, recorder record.EventRecorder) *StatusReconciler {
 	return &StatusReconciler{
-		DefaultSubReconciler: utils.NewDefaultSubReconciler(client),
+		DefaultSubReconciler: utils.NewDefaultSubReconciler(client, recorder),
 	}
 }
 
@@ -39,14 +42,15 @@ func (r *StatusReconciler) Reconcile(ctx context.Context, mariadb *v1alpha1.Mari
 		return ctrl.Result{}, err
 	}
 
-	ready := deployment != nil &&
-		deployment.Status.ReadyReplicas > 0 &&
-		deployment.Status.Replicas == deployment.Status.ReadyReplicas
-	if mariadb.Status.Ready == ready {
+	readyCondition := utils.GetDeploymentCondition(v1alpha1.ConditionTypeReady, mariadb.Generation, deployment)
+	ready := readyCondition.Status == metav1.ConditionTrue
+	conditionChanged := meta.SetStatusCondition(&mariadb.Status.Conditions, readyCondition)
+	if mariadb.Status.Ready == ready && mariadb.Status.ObservedGeneration == mariadb.Generation && !conditionChanged {
 		return ctrl.Result{}, nil
 	}
 
 	mariadb.Status.Ready = ready
+	mariadb.Status.ObservedGeneration = mariadb.Generation
 	if err := r.GetClient().Status().Update(ctx, mariadb); err != nil {
 		return ctrl.Result{}, err
 	}
@@ -21,7 +21,7 @@ var _ = Describe("DeploymentReconciler", func() {
 	var reconciler *utils.Reconciler[*v1alpha1.Minio]
 
 	BeforeEach(func() {
-		reconciler = minio.NewReconciler(k8sClient, minio.WithDeploymentReconciler())
+		reconciler = minio.NewReconciler(k8sClient, recorder, minio.WithDeploymentReconciler())
 	})
 
 	AfterEach(func(ctx SpecContext) {
@@ -7,6 +7,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -22,9 +23,9 @@ type PersistentVolumeClaimReconciler struct {
 	utils.DefaultSubReconciler
 }
 
-func NewPersistentVolumeClaimReconciler(client client.Client) *PersistentVolumeClaimReconciler {
+func NewPersistentVolumeClaimReconciler(client client.Client, recorder record.EventRecorder) *PersistentVolumeClaimReconciler {
 	return &PersistentVolumeClaimReconciler{
-		DefaultSubReconciler: utils.NewDefaultSubReconciler(client),
+		DefaultSubReconciler: utils.NewDefaultSubReconciler(client, recorder),
 	}
 }
 
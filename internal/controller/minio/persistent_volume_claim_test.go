@@ -20,7 +20,7 @@ var _ = Describe("PersistentVolumeClaimReconciler", func() {
 	var reconciler *utils.Reconciler[*v1alpha1.Minio]
 
 	BeforeEach(func() {
-		reconciler = minio.NewReconciler(k8sClient, minio.WithPersistentVolumeClaimReconciler())
+		reconciler = minio.NewReconciler(k8sClient, recorder, minio.WithPersistentVolumeClaimReconciler())
 	})
 
 	AfterEach(func(ctx SpecContext) {
@@ -17,7 +17,7 @@ var _ = Describe("Reconciler", func() {
 	var reconciler *utils.Reconciler[*v1alpha1.Minio]
 
 	BeforeEach(func() {
-		reconciler = minio.NewReconciler(k8sClient, minio.WithDefaultReconcilers())
+		reconciler = minio.NewReconciler(k8sClient, recorder, minio.WithDefaultReconcilers())
 	})
 
 	AfterEach(func(ctx SpecContext) {
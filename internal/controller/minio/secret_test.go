@@ -18,7 +18,7 @@ var _ = Describe("SecretReconciler", func() {
 	var reconciler *utils.Reconciler[*v1alpha1.Minio]
 
 	BeforeEach(func() {
-		reconciler = minio.NewReconciler(k8sClient, minio.WithSecretReconciler())
+		reconciler = minio.NewReconciler(k8sClient, recorder, minio.WithSecretReconciler())
 	})
 
 	AfterEach(func(ctx SpecContext) {
@@ -7,6 +7,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -22,9 +23,9 @@ type ServiceReconciler struct {
 	utils.DefaultSubReconciler
 }
 
-func NewServiceReconciler(client client.Client) *ServiceReconciler {
+func NewServiceReconciler(client client.Client, recorder record.EventRecorder) *ServiceReconciler {
 	return &ServiceReconciler{
-		DefaultSubReconciler: utils.NewDefaultSubReconciler(client),
+		DefaultSubReconciler: utils.NewDefaultSubReconciler(client, recorder),
 	}
 }
 
@@ -32,6 +33,11 @@ func (r *ServiceReconciler) SetupWithManager(ctrlBuilder *builder.Builder) *buil
 	return ctrlBuilder.Owns(&corev1.Service{})
 }
 
+func (r *ServiceReconciler) Blocking() bool {
+	// The Minio deployment starts without its service. Only the bucket setup of CTFd needs it, which retries on its own.
+	return false
+}
+
 func (r *ServiceReconciler) Reconcile(ctx context.Context, minio *v1alpha1.Minio) (ctrl.Result, error) {
 	currentSpec, err := r.getService(ctx, minio)
 	if err != nil {
@@ -18,7 +18,7 @@ var _ = Describe("ServiceAccountReconciler", func() {
 	var reconciler *utils.Reconciler[*v1alpha1.Minio]
 
 	BeforeEach(func() {
-		reconciler = minio.NewReconciler(k8sClient, minio.WithServiceAccountReconciler())
+		reconciler = minio.NewReconciler(k8sClient, recorder, minio.WithServiceAccountReconciler())
 	})
 
 	AfterEach(func(ctx SpecContext) {
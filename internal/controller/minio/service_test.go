@@ -18,7 +18,7 @@ var _ = Describe("ServiceReconciler", func() {
 	var reconciler *utils.Reconciler[*v1alpha1.Minio]
 
 	BeforeEach(func() {
-		reconciler = minio.NewReconciler(k8sClient, minio.WithServiceReconciler())
+		reconciler = minio.NewReconciler(k8sClient, recorder, minio.WithServiceReconciler())
 	})
 
 	AfterEach(func(ctx SpecContext) {
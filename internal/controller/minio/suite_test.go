@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 
@@ -17,6 +18,7 @@ import (
 var (
 	testEnv   *envtest.Environment
 	k8sClient client.Client
+	recorder  *record.FakeRecorder
 )
 
 func TestReconciler(t *testing.T) {
@@ -28,6 +30,12 @@ var _ = BeforeSuite(func() {
 	testEnv, k8sClient = testutils.SetupTestEnv()
 })
 
+var _ = BeforeEach(func() {
+	// Every test gets a fresh recorder, so that the events of previous tests do not leak into the current test. The
+	// buffer needs to be large enough to not block the reconciler.
+	recorder = record.NewFakeRecorder(1000)
+})
+
 var _ = AfterSuite(func() {
 	Expect(testEnv.Stop()).To(Succeed())
 })
@@ -12,6 +12,8 @@ import (
 	"github.com/backbone81/ctf-ui-operator/internal/controller/redis"
 )
 
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
 // Reconciler is the main reconciler of this operator. It is responsible for registering and running all
 // top level reconcilers.
 type Reconciler struct {
@@ -50,52 +52,53 @@ type SubReconciler interface {
 // ReconcilerOption is an option which can be applied to the reconciler.
 type ReconcilerOption func(reconciler *Reconciler)
 
-// WithDefaultReconcilers returns a reconciler option which enables the default sub-reconcilers.
+// WithDefaultReconcilers returns a reconciler option which enables the default sub-reconcilers. All sub-reconcilers
+// emit their events through the given event recorder.
 func WithDefaultReconcilers(recorder record.EventRecorder) ReconcilerOption {
 	return func(reconciler *Reconciler) {
-		WithMariaDBReconciler()(reconciler)
-		WithMinioReconciler()(reconciler)
-		WithRedisReconciler()(reconciler)
-		WithCTFdReconciler()(reconciler)
+		WithMariaDBReconciler(recorder)(reconciler)
+		WithMinioReconciler(recorder)(reconciler)
+		WithRedisReconciler(recorder)(reconciler)
+		WithCTFdReconciler(recorder)(reconciler)
 	}
 }
 
 // WithCTFdReconciler returns a reconciler option which enables the CTFd sub-reconciler.
-func WithCTFdReconciler() ReconcilerOption {
+func WithCTFdReconciler(recorder record.EventRecorder) ReconcilerOption {
 	return func(reconciler *Reconciler) {
 		reconciler.subReconcilers = append(
 			reconciler.subReconcilers,
-			ctfd.NewReconciler(reconciler.client, ctfd.WithDefaultReconcilers()),
+			ctfd.NewReconciler(reconciler.client, recorder, ctfd.WithDefaultReconcilers()),
 		)
 	}
 }
 
 // WithMariaDBReconciler returns a reconciler option which enables the MariaDB sub-reconciler.
-func WithMariaDBReconciler() ReconcilerOption {
+func WithMariaDBReconciler(recorder record.EventRecorder) ReconcilerOption {
 	return func(reconciler *Reconciler) {
 		reconciler.subReconcilers = append(
 			reconciler.subReconcilers,
-			mariadb.NewReconciler(reconciler.client, mariadb.WithDefaultReconcilers()),
+			mariadb.NewReconciler(reconciler.client, recorder, mariadb.WithDefaultReconcilers()),
 		)
 	}
 }
 
 // WithMinioReconciler returns a reconciler option which enables the Minio sub-reconciler.
-func WithMinioReconciler() ReconcilerOption {
+func WithMinioReconciler(recorder record.EventRecorder) ReconcilerOption {
 	return func(reconciler *Reconciler) {
 		reconciler.subReconcilers = append(
 			reconciler.subReconcilers,
-			minio.NewReconciler(reconciler.client, minio.WithDefaultReconcilers()),
+			minio.NewReconciler(reconciler.client, recorder, minio.WithDefaultReconcilers()),
 		)
 	}
 }
 
 // WithRedisReconciler returns a reconciler option which enables the Redis sub-reconciler.
-func WithRedisReconciler() ReconcilerOption {
+func WithRedisReconciler(recorder record.EventRecorder) ReconcilerOption {
 	return func(reconciler *Reconciler) {
 		reconciler.subReconcilers = append(
 			reconciler.subReconcilers,
-			redis.NewReconciler(reconciler.client, redis.WithDefaultReconcilers()),
+			redis.NewReconciler(reconciler.client, recorder, redis.WithDefaultReconcilers()),
 		)
 	}
 }
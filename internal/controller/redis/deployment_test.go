@@ -21,7 +21,7 @@ var _ = Describe("DeploymentReconciler", func() {
 	var reconciler *utils.Reconciler[*v1alpha1.Redis]
 
 	BeforeEach(func() {
-		reconciler = redis.NewReconciler(k8sClient, redis.WithDeploymentReconciler())
+		reconciler = redis.NewReconciler(k8sClient, recorder, redis.WithDeploymentReconciler())
 	})
 
 	AfterEach(func(ctx SpecContext) {
@@ -20,7 +20,7 @@ var _ = Describe("PersistentVolumeClaimReconciler", func() {
 	var reconciler *utils.Reconciler[*v1alpha1.Redis]
 
 	BeforeEach(func() {
-		reconciler = redis.NewReconciler(k8sClient, redis.WithPersistentVolumeClaimReconciler())
+		reconciler = redis.NewReconciler(k8sClient, recorder, redis.WithPersistentVolumeClaimReconciler())
 	})
 
 	AfterEach(func(ctx SpecContext) {
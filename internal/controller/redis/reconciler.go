@@ -1,6 +1,7 @@
 package redis
 
 import (
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/backbone81/ctf-ui-operator/api/v1alpha1"
@@ -11,9 +12,10 @@ import (
 // +kubebuilder:rbac:groups=ui.ctf.backbone81,resources=redis/finalizers,verbs=update
 // +kubebuilder:rbac:groups=ui.ctf.backbone81,resources=redis/status,verbs=get;update;patch
 
-func NewReconciler(client client.Client, options ...utils.ReconcilerOption[*v1alpha1.Redis]) *utils.Reconciler[*v1alpha1.Redis] {
+func NewReconciler(client client.Client, recorder record.EventRecorder, options ...utils.ReconcilerOption[*v1alpha1.Redis]) *utils.Reconciler[*v1alpha1.Redis] {
 	return utils.NewReconciler[*v1alpha1.Redis](
 		client,
+		recorder,
 		func() *v1alpha1.Redis {
 			return &v1alpha1.Redis{}
 		},
@@ -34,30 +36,30 @@ func WithDefaultReconcilers() utils.ReconcilerOption[*v1alpha1.Redis] {
 
 func WithDeploymentReconciler() utils.ReconcilerOption[*v1alpha1.Redis] {
 	return func(reconciler *utils.Reconciler[*v1alpha1.Redis]) {
-		reconciler.AppendSubReconciler(NewDeploymentReconciler(reconciler.GetClient()))
+		reconciler.AppendSubReconciler(NewDeploymentReconciler(reconciler.GetClient(), reconciler.GetRecorder()))
 	}
 }
 
 func WithPersistentVolumeClaimReconciler() utils.ReconcilerOption[*v1alpha1.Redis] {
 	return func(reconciler *utils.Reconciler[*v1alpha1.Redis]) {
-		reconciler.AppendSubReconciler(NewPersistentVolumeClaimReconciler(reconciler.GetClient()))
+		reconciler.AppendSubReconciler(NewPersistentVolumeClaimReconciler(reconciler.GetClient(), reconciler.GetRecorder()))
 	}
 }
 
 func WithServiceReconciler() utils.ReconcilerOption[*v1alpha1.Redis] {
 	return func(reconciler *utils.Reconciler[*v1alpha1.Redis]) {
-		reconciler.AppendSubReconciler(NewServiceReconciler(reconciler.GetClient()))
+		reconciler.AppendSubReconciler(NewServiceReconciler(reconciler.GetClient(), reconciler.GetRecorder()))
 	}
 }
 
 func WithServiceAccountReconciler() utils.ReconcilerOption[*v1alpha1.Redis] {
 	return func(reconciler *utils.Reconciler[*v1alpha1.Redis]) {
-		reconciler.AppendSubReconciler(NewServiceAccountReconciler(reconciler.GetClient()))
+		reconciler.AppendSubReconciler(NewServiceAccountReconciler(reconciler.GetClient(), reconciler.GetRecorder()))
 	}
 }
 
 func WithStatusReconciler() utils.ReconcilerOption[*v1alpha1.Redis] {
 	return func(reconciler *utils.Reconciler[*v1alpha1.Redis]) {
-		reconciler.AppendSubReconciler(NewStatusReconciler(reconciler.GetClient()))
+		reconciler.AppendSubReconciler(NewStatusReconciler(reconciler.GetClient(), reconciler.GetRecorder()))
 	}
 }
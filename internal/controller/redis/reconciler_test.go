@@ -17,7 +17,7 @@ var _ = Describe("Reconciler", func() {
 	var reconciler *utils.Reconciler[*v1alpha1.Redis]
 
 	BeforeEach(func() {
-		reconciler = redis.NewReconciler(k8sClient, redis.WithDefaultReconcilers())
+		reconciler = redis.NewReconciler(k8sClient, recorder, redis.WithDefaultReconcilers())
 	})
 
 	AfterEach(func(ctx SpecContext) {
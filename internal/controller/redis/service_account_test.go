@@ -18,7 +18,7 @@ var _ = Describe("ServiceAccountReconciler", func() {
 	var reconciler *utils.Reconciler[*v1alpha1.Redis]
 
 	BeforeEach(func() {
-		reconciler = redis.NewReconciler(k8sClient, redis.WithServiceAccountReconciler())
+		reconciler = redis.NewReconciler(k8sClient, recorder, redis.WithServiceAccountReconciler())
 	})
 
 	AfterEach(func(ctx SpecContext) {
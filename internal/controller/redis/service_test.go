@@ -18,7 +18,7 @@ var _ = Describe("ServiceReconciler", func() {
 	var reconciler *utils.Reconciler[*v1alpha1.Redis]
 
 	BeforeEach(func() {
-		reconciler = redis.NewReconciler(k8sClient, redis.WithServiceReconciler())
+		reconciler = redis.NewReconciler(k8sClient, recorder, redis.WithServiceReconciler())
 	})
 
 	AfterEach(func(ctx SpecContext) {
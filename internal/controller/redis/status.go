@@ -4,6 +4,9 @@ import (
 	"context"
 
 	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -18,9 +21,9 @@ type StatusReconciler struct {
 	utils.DefaultSubReconciler
 }
 
-func NewStatusReconciler(client client.Client) *StatusReconciler {
+func NewStatusReconciler(client client.Client, recorder record.EventRecorder) *StatusReconciler {
 	return &StatusReconciler{
-		DefaultSubReconciler: utils.NewDefaultSubReconciler(client),
+		DefaultSubReconciler: utils.NewDefaultSubReconciler(client, recorder),
 	}
 }
 
@@ -39,14 +42,15 @@ func (r *StatusReconciler) Reconcile(ctx context.Context, redis *v1alpha1.Redis)
 		return ctrl.Result{}, err
 	}
 
-	ready := deployment != nil &&
-		deployment.Status.ReadyReplicas > 0 &&
-		deployment.Status.Replicas == deployment.Status.ReadyReplicas
-	if redis.Status.Ready == ready {
+	readyCondition := utils.GetDeploymentCondition(v1alpha1.ConditionTypeReady, redis.Generation, deployment)
+	ready := readyCondition.Status == metav1.ConditionTrue
+	conditionChanged := meta.SetStatusCondition(&redis.Status.Conditions, readyCondition)
+	if redis.Status.Ready == ready && redis.Status.ObservedGeneration == redis.Generation && !conditionChanged {
 		return ctrl.Result{}, nil
 	}
 
 	redis.Status.Ready = ready
+	redis.Status.ObservedGeneration = redis.Generation
 	if err := r.GetClient().Status().Update(ctx, redis); err != nil {
 		return ctrl.Result{}, err
 	}
@@ -20,7 +20,7 @@ var _ = Describe("StatusReconciler", func() {
 	var reconciler *utils.Reconciler[*v1alpha1.Redis]
 
 	BeforeEach(func() {
-		reconciler = redis.NewReconciler(k8sClient, redis.WithStatusReconciler())
+		reconciler = redis.NewReconciler(k8sClient, recorder, redis.WithStatusReconciler())
 	})
 
 	AfterEach(func(ctx SpecContext) {
@@ -74,6 +74,11 @@ var _ = Describe("StatusReconciler", func() {
 		By("verify all postconditions")
 		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
 		Expect(instance.Status.Ready).To(BeFalse())
+		Expect(instance.Status.ObservedGeneration).To(Equal(instance.Generation))
+		Expect(instance.Status.Conditions).To(ContainElement(And(
+			HaveField("Type", v1alpha1.ConditionTypeReady),
+			HaveField("Status", metav1.ConditionFalse),
+		)))
 	})
 
 	It("should set the status to ready when all replicas are ready", func(ctx SpecContext) {
@@ -123,5 +128,10 @@ var _ = Describe("StatusReconciler", func() {
 		By("verify all postconditions")
 		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&instance), &instance)).To(Succeed())
 		Expect(instance.Status.Ready).To(BeTrue())
+		Expect(instance.Status.ObservedGeneration).To(Equal(instance.Generation))
+		Expect(instance.Status.Conditions).To(ContainElement(And(
+			HaveField("Type", v1alpha1.ConditionTypeReady),
+			HaveField("Status", metav1.ConditionTrue),
+		)))
 	})
 })
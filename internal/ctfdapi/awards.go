@@ -0,0 +1,123 @@
+package ctfdapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
+	"path"
+	"strconv"
+)
+
+const (
+	awardsPath = "/api/v1/awards"
+)
+
+// AwardTypeStandard is the award type for awards which add their value to the score.
+const AwardTypeStandard = "standard"
+
+//nolint:tagliatelle // This is an externally controlled data type.
+type Award struct {
+	// Id is the unique id of the award. This field needs to be configured as omitempty. Otherwise, a create call
+	// will submit the Id to the API endpoint, which will break database constraints.
+	Id int `json:"id,omitempty"`
+
+	// UserId is the user who received the award. In team mode, CTFd fills in the team of the user when no team is
+	// given.
+	UserId *int `json:"user_id,omitempty"`
+
+	// TeamId is the team which received the award. It is only relevant in team mode.
+	TeamId *int `json:"team_id,omitempty"`
+
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	// Value is added to the score. Negative values deduct points.
+	Value    int    `json:"value"`
+	Category string `json:"category"`
+	Icon     string `json:"icon"`
+}
+
+// ListAwards returns all awards of all users and teams.
+func (c *Client) ListAwards(ctx context.Context) ([]Award, error) {
+	return collectPages(c.IterateAwards(ctx))
+}
+
+// IterateAwards returns an iterator over all awards of all users and teams. The pages are fetched while iterating.
+func (c *Client) IterateAwards(ctx context.Context) iter.Seq2[Award, error] {
+	return iteratePages[Award](ctx, c, awardsPath, nil)
+}
+
+type CreateAwardResponse struct {
+	Success bool  `json:"success"`
+	Data    Award `json:"data"`
+}
+
+// CreateAward creates a new award. Awards can not be updated in CTFd. To change an award, it needs to be deleted and
+// created again.
+func (c *Client) CreateAward(ctx context.Context, award Award) (Award, error) {
+	// Creating an award with a specific ID will sooner or later result in violated database constraints.
+	// To prevent that, we reset the award id.
+	award.Id = 0
+	if award.Type == "" {
+		award.Type = AwardTypeStandard
+	}
+	data, err := c.sendPostRequest(ctx, awardsPath, award)
+	if err != nil {
+		return Award{}, err
+	}
+
+	var response CreateAwardResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return Award{}, err
+	}
+
+	if !response.Success {
+		return response.Data, errors.New("the API request did not succeed")
+	}
+	return response.Data, nil
+}
+
+type DeleteAwardResponse struct {
+	Success bool `json:"success"`
+}
+
+func (c *Client) DeleteAward(ctx context.Context, id int) error {
+	data, err := c.sendDeleteRequest(ctx, path.Join(awardsPath, strconv.Itoa(id)), nil)
+	if err != nil {
+		return err
+	}
+
+	var response DeleteAwardResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return err
+	}
+
+	if !response.Success {
+		return errors.New("the API request did not succeed")
+	}
+	return nil
+}
+
+type GetAwardResponse struct {
+	Success bool  `json:"success"`
+	Data    Award `json:"data"`
+}
+
+func (c *Client) GetAward(ctx context.Context, id int) (Award, error) {
+	data, err := c.sendGetRequest(ctx, path.Join(awardsPath, strconv.Itoa(id)), nil)
+	if err != nil {
+		return Award{}, err
+	}
+
+	var response GetAwardResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return Award{}, err
+	}
+
+	if !response.Success {
+		return response.Data, errors.New("the API request did not succeed")
+	}
+	return response.Data, nil
+}
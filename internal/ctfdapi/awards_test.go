@@ -0,0 +1,76 @@
+package ctfdapi_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/backbone81/ctf-ui-operator/internal/ctfdapi"
+)
+
+var _ = Describe("Awards", func() {
+	var (
+		ctfdClient *ctfdapi.Client
+		team       ctfdapi.Team
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		var err error
+		ctfdClient, err = ctfdapi.NewClient(endpointUrl, accessToken)
+		Expect(err).ToNot(HaveOccurred())
+
+		team, err = ctfdClient.CreateTeam(ctx, NewTestTeam())
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should create a new award", func(ctx SpecContext) {
+		beforeAwards, err := ctfdClient.ListAwards(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		award, err := ctfdClient.CreateAward(ctx, ctfdapi.Award{
+			TeamId:      &team.Id,
+			Name:        "Write-up",
+			Description: "Excellent write-up for the web challenge.",
+			Value:       50,
+			Category:    "Bonus",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(award.Type).To(Equal(ctfdapi.AwardTypeStandard))
+
+		afterAwards, err := ctfdClient.ListAwards(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(afterAwards).To(HaveLen(len(beforeAwards) + 1))
+	})
+
+	It("should get an existing award", func(ctx SpecContext) {
+		award, err := ctfdClient.CreateAward(ctx, ctfdapi.Award{
+			TeamId: &team.Id,
+			Name:   "Rule violation",
+			Value:  -100,
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		awardGet, err := ctfdClient.GetAward(ctx, award.Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(awardGet.Name).To(Equal("Rule violation"))
+		Expect(awardGet.Value).To(Equal(-100))
+		Expect(awardGet.TeamId).To(HaveValue(Equal(team.Id)))
+	})
+
+	It("should delete an award", func(ctx SpecContext) {
+		award, err := ctfdClient.CreateAward(ctx, ctfdapi.Award{
+			TeamId: &team.Id,
+			Name:   "Write-up",
+			Value:  50,
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		beforeAwards, err := ctfdClient.ListAwards(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ctfdClient.DeleteAward(ctx, award.Id)).To(Succeed())
+
+		afterAwards, err := ctfdClient.ListAwards(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(afterAwards).To(HaveLen(len(beforeAwards) - 1))
+	})
+})
@@ -0,0 +1,64 @@
+package ctfdapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path"
+	"strconv"
+)
+
+// ChallengeRequirements describes what needs to be done before a challenge is unlocked.
+type ChallengeRequirements struct {
+	// Prerequisites are the ids of the challenges which need to be solved before the challenge is unlocked.
+	Prerequisites []int `json:"prerequisites"`
+
+	// Anonymize shows the locked challenge without its details instead of hiding it completely.
+	Anonymize bool `json:"anonymize,omitempty"`
+}
+
+type GetChallengeRequirementsResponse struct {
+	Success bool                  `json:"success"`
+	Data    ChallengeRequirements `json:"data"`
+}
+
+func (c *Client) GetChallengeRequirements(ctx context.Context, challengeId int) (ChallengeRequirements, error) {
+	data, err := c.sendGetRequest(ctx, path.Join(challengesPath, strconv.Itoa(challengeId), "requirements"), nil)
+	if err != nil {
+		return ChallengeRequirements{}, err
+	}
+
+	var response GetChallengeRequirementsResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return ChallengeRequirements{}, err
+	}
+
+	if !response.Success {
+		return response.Data, errors.New("the API request did not succeed")
+	}
+	return response.Data, nil
+}
+
+type UpdateChallengeRequirementsRequest struct {
+	Requirements ChallengeRequirements `json:"requirements"`
+}
+
+// UpdateChallengeRequirements replaces the requirements of the challenge with the given requirements.
+func (c *Client) UpdateChallengeRequirements(ctx context.Context, challengeId int, requirements ChallengeRequirements) error {
+	data, err := c.sendPatchRequest(ctx, path.Join(challengesPath, strconv.Itoa(challengeId)), UpdateChallengeRequirementsRequest{
+		Requirements: requirements,
+	})
+	if err != nil {
+		return err
+	}
+
+	var response UpdateChallengeResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return err
+	}
+
+	if !response.Success {
+		return errors.New("the API request did not succeed")
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package ctfdapi_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/backbone81/ctf-ui-operator/internal/ctfdapi"
+)
+
+var _ = Describe("ChallengeRequirements", func() {
+	var ctfdClient *ctfdapi.Client
+
+	BeforeEach(func(ctx SpecContext) {
+		var err error
+		ctfdClient, err = ctfdapi.NewClient(endpointUrl, accessToken)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should get empty requirements", func(ctx SpecContext) {
+		challenge, err := ctfdClient.CreateChallenge(ctx, ctfdapi.Challenge{
+			Name: "Test Challenge",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		requirements, err := ctfdClient.GetChallengeRequirements(ctx, challenge.Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requirements.Prerequisites).To(BeEmpty())
+	})
+
+	It("should update requirements", func(ctx SpecContext) {
+		prerequisite, err := ctfdClient.CreateChallenge(ctx, ctfdapi.Challenge{
+			Name: "Prerequisite Challenge",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		challenge, err := ctfdClient.CreateChallenge(ctx, ctfdapi.Challenge{
+			Name: "Test Challenge",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ctfdClient.UpdateChallengeRequirements(ctx, challenge.Id, ctfdapi.ChallengeRequirements{
+			Prerequisites: []int{prerequisite.Id},
+		})).To(Succeed())
+
+		requirements, err := ctfdClient.GetChallengeRequirements(ctx, challenge.Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requirements.Prerequisites).To(ConsistOf(prerequisite.Id))
+	})
+})
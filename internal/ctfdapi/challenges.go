@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"iter"
 	"path"
 	"strconv"
 )
@@ -15,39 +16,35 @@ const (
 type Challenge struct {
 	// Id is the unique id of the challenge. This field needs to be configured as omitempty. Otherwise, a create call
 	// will submit the Id to the API endpoint, which will break database constraints.
-	Id             int    `json:"id,omitempty"`
-	Name           string `json:"name,omitempty"`
-	Description    string `json:"description,omitempty"`
-	Attribution    string `json:"attribution,omitempty"`
-	ConnectionInfo string `json:"connection_info,omitempty"` //nolint:tagliatelle
-	NextId         *int   `json:"next_id,omitempty"`         //nolint:tagliatelle
-	MaxAttempts    int    `json:"max_attempts,omitempty"`    //nolint:tagliatelle
-	Value          int    `json:"value,omitempty"`
-	Category       string `json:"category,omitempty"`
-	Type           string `json:"type,omitempty"`
-	State          string `json:"state,omitempty"`
-}
-
-type ListChallengeResponse struct {
-	Success bool        `json:"success"`
-	Data    []Challenge `json:"data"`
+	Id          int    `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	NextId      *int   `json:"next_id,omitempty"` //nolint:tagliatelle
+	Value       int    `json:"value,omitempty"`
+	Category    string `json:"category,omitempty"`
+	Type        string `json:"type,omitempty"`
+	State       string `json:"state,omitempty"`
+
+	// Attribution, ConnectionInfo and MaxAttempts are pointers, so that they can be cleared with an update call.
+	Attribution    *string `json:"attribution,omitempty"`
+	ConnectionInfo *string `json:"connection_info,omitempty"` //nolint:tagliatelle
+	MaxAttempts    *int    `json:"max_attempts,omitempty"`    //nolint:tagliatelle
+
+	// Initial, Decay, Minimum and Function are only used by dynamic challenges. The value of a dynamic challenge is
+	// calculated by CTFd from those fields.
+	Initial  int    `json:"initial,omitempty"`
+	Decay    int    `json:"decay,omitempty"`
+	Minimum  int    `json:"minimum,omitempty"`
+	Function string `json:"function,omitempty"`
 }
 
 func (c *Client) ListChallenges(ctx context.Context) ([]Challenge, error) {
-	data, err := c.sendGetRequest(ctx, challengesPath, map[string]string{"view": "admin"})
-	if err != nil {
-		return nil, err
-	}
-
-	var response ListChallengeResponse
-	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, err
-	}
+	return collectPages(c.IterateChallenges(ctx))
+}
 
-	if !response.Success {
-		return response.Data, errors.New("the API request did not succeed")
-	}
-	return response.Data, nil
+// IterateChallenges returns an iterator over all challenges. The pages are fetched while iterating.
+func (c *Client) IterateChallenges(ctx context.Context) iter.Seq2[Challenge, error] {
+	return iteratePages[Challenge](ctx, c, challengesPath, map[string]string{"view": "admin"})
 }
 
 type CreateChallengeResponse struct {
@@ -85,7 +82,7 @@ type DeleteChallengeResponse struct {
 }
 
 func (c *Client) DeleteChallenge(ctx context.Context, id int) error {
-	data, err := c.sendDeleteRequest(ctx, path.Join(challengesPath, strconv.Itoa(id)))
+	data, err := c.sendDeleteRequest(ctx, path.Join(challengesPath, strconv.Itoa(id)), nil)
 	if err != nil {
 		return err
 	}
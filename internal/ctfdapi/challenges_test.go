@@ -29,6 +29,27 @@ var _ = Describe("Challenges", func() {
 		Expect(afterChallenges).To(HaveLen(len(beforeChallenges) + 1))
 	})
 
+	It("should create a new dynamic challenge", func(ctx SpecContext) {
+		challenge, err := ctfdClient.CreateChallenge(ctx, ctfdapi.Challenge{
+			Name:     "Test Challenge",
+			Type:     "dynamic",
+			Initial:  500,
+			Decay:    10,
+			Minimum:  100,
+			Function: "linear",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		challengeGet, err := ctfdClient.GetChallenge(ctx, challenge.Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(challengeGet.Type).To(Equal("dynamic"))
+		Expect(challengeGet.Initial).To(Equal(500))
+		Expect(challengeGet.Decay).To(Equal(10))
+		Expect(challengeGet.Minimum).To(Equal(100))
+		Expect(challengeGet.Function).To(Equal("linear"))
+		Expect(challengeGet.Value).To(Equal(500))
+	})
+
 	It("should get an existing challenge", func(ctx SpecContext) {
 		challenge, err := ctfdClient.CreateChallenge(ctx, ctfdapi.Challenge{
 			Name: "Test Challenge",
@@ -7,8 +7,13 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"iter"
+	"maps"
+	"mime/multipart"
 	"net/http"
 	"regexp"
+	"slices"
+	"strconv"
 )
 
 var nonceRegex = regexp.MustCompile(`<input id="nonce" name="nonce" type="hidden" value="([^"]+)">`)
@@ -48,7 +53,7 @@ func (c *Client) getByRegex(ctx context.Context, path string, regex *regexp.Rege
 	}
 
 	if response.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("unexpected status code %d: %s", response.StatusCode, response.Status)
+		return "", newAPIError(response, pageData)
 	}
 
 	matches := regex.FindSubmatch(pageData)
@@ -79,6 +84,35 @@ func (c *Client) sendPostRequest(ctx context.Context, path string, payload any)
 	return c.executeRequest(request)
 }
 
+// sendMultipartPostRequest sends the fields and the content of a single file as multipart form data. This is required
+// by endpoints which receive files, as those do not accept JSON.
+func (c *Client) sendMultipartPostRequest(ctx context.Context, path string, fields map[string]string, fileField string, fileName string, content io.Reader) ([]byte, error) {
+	var payloadData bytes.Buffer
+	writer := multipart.NewWriter(&payloadData)
+	for _, key := range slices.Sorted(maps.Keys(fields)) {
+		if err := writer.WriteField(key, fields[key]); err != nil {
+			return nil, fmt.Errorf("writing multipart field: %w", err)
+		}
+	}
+	fileWriter, err := writer.CreateFormFile(fileField, fileName)
+	if err != nil {
+		return nil, fmt.Errorf("creating multipart file: %w", err)
+	}
+	if _, err := io.Copy(fileWriter, content); err != nil {
+		return nil, fmt.Errorf("writing multipart file: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("closing multipart writer: %w", err)
+	}
+
+	request, err := c.prepareRequest(ctx, http.MethodPost, path, nil, payloadData.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	return c.executeRequest(request)
+}
+
 func (c *Client) sendPatchRequest(ctx context.Context, path string, payload any) ([]byte, error) {
 	payloadData, err := json.Marshal(payload)
 	if err != nil {
@@ -92,8 +126,23 @@ func (c *Client) sendPatchRequest(ctx context.Context, path string, payload any)
 	return c.executeRequest(request)
 }
 
-func (c *Client) sendDeleteRequest(ctx context.Context, path string) ([]byte, error) {
-	request, err := c.prepareRequest(ctx, http.MethodDelete, path, nil, nil)
+func (c *Client) sendDeleteRequest(ctx context.Context, path string, queryParameter map[string]string) ([]byte, error) {
+	request, err := c.prepareRequest(ctx, http.MethodDelete, path, queryParameter, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.executeRequest(request)
+}
+
+// sendDeleteRequestWithPayload sends a DELETE request with a JSON body. This is required by endpoints which identify
+// the object to delete through the payload instead of the path.
+func (c *Client) sendDeleteRequestWithPayload(ctx context.Context, path string, payload any) ([]byte, error) {
+	payloadData, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling payload into JSON: %w", err)
+	}
+
+	request, err := c.prepareRequest(ctx, http.MethodDelete, path, nil, payloadData)
 	if err != nil {
 		return nil, err
 	}
@@ -124,7 +173,49 @@ func (c *Client) prepareRequest(ctx context.Context, method string, path string,
 	return request, nil
 }
 
+// executeRequest sends the request and returns the response body. Idempotent requests are retried according to the
+// retry policy of the client, when they fail with an error which might go away on its own.
 func (c *Client) executeRequest(request *http.Request) ([]byte, error) {
+	maxAttempts := 1
+	if isIdempotentMethod(request.Method) {
+		maxAttempts = max(c.retryPolicy.MaxAttempts, 1)
+	}
+
+	var err error
+	for attempt := range maxAttempts {
+		if attempt != 0 {
+			if waitErr := c.retryPolicy.wait(request.Context(), attempt); waitErr != nil {
+				return nil, errors.Join(err, waitErr)
+			}
+			if request, err = c.rewindRequest(request); err != nil {
+				return nil, err
+			}
+		}
+
+		var responseData []byte
+		responseData, err = c.executeRequestOnce(request)
+		if err == nil || !isRetryableError(err) {
+			return responseData, err
+		}
+	}
+	return nil, err
+}
+
+// rewindRequest returns a copy of the request with a fresh body, as the body of the request was already consumed by
+// the previous attempt.
+func (c *Client) rewindRequest(request *http.Request) (*http.Request, error) {
+	result := request.Clone(request.Context())
+	if request.GetBody != nil {
+		body, err := request.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("rewinding request body: %w", err)
+		}
+		result.Body = body
+	}
+	return result, nil
+}
+
+func (c *Client) executeRequestOnce(request *http.Request) ([]byte, error) {
 	response, err := c.client.Do(request)
 	if err != nil {
 		return nil, fmt.Errorf("executing HTTP request: %w", err)
@@ -137,7 +228,101 @@ func (c *Client) executeRequest(request *http.Request) ([]byte, error) {
 	}
 
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code %d: %s", response.StatusCode, response.Status)
+		return nil, newAPIError(response, responseData)
 	}
 	return responseData, nil
 }
+
+// ListResponse is the response of all list endpoints. Endpoints which return their results in pages provide the
+// pagination in Meta. Other endpoints return all results at once and leave Meta empty.
+type ListResponse[T any] struct {
+	Success bool     `json:"success"`
+	Data    []T      `json:"data"`
+	Meta    ListMeta `json:"meta"`
+}
+
+// getPage returns a single page of the list endpoint at the given path. The first page is 1.
+func getPage[T any](ctx context.Context, c *Client, path string, queryParameter map[string]string, page int) (ListResponse[T], error) {
+	if page != 1 {
+		// The first page is requested without the page parameter, so that endpoints without pagination receive the
+		// same request as always.
+		queryParameter = maps.Clone(queryParameter)
+		if queryParameter == nil {
+			queryParameter = make(map[string]string)
+		}
+		queryParameter["page"] = strconv.Itoa(page)
+	}
+	data, err := c.sendGetRequest(ctx, path, queryParameter)
+	if err != nil {
+		return ListResponse[T]{}, err
+	}
+
+	var response ListResponse[T]
+	if err := json.Unmarshal(data, &response); err != nil {
+		return ListResponse[T]{}, err
+	}
+
+	if !response.Success {
+		return response, errors.New("the API request did not succeed")
+	}
+	return response, nil
+}
+
+// iteratePages returns an iterator over all entries of the list endpoint at the given path. The pages are fetched one
+// after the other while iterating, so callers which stop early do not fetch the remaining pages. The iteration ends
+// after the first error.
+func iteratePages[T any](ctx context.Context, c *Client, path string, queryParameter map[string]string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		page := 1
+		for {
+			response, err := getPage[T](ctx, c, path, queryParameter, page)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, entry := range response.Data {
+				if !yield(entry, nil) {
+					return
+				}
+			}
+
+			// Endpoints without pagination do not provide a next page. We also stop when the next page does not move
+			// forward, as we would request the same pages over and over again otherwise.
+			next := response.Meta.Pagination.Next
+			if next == nil || *next <= page {
+				return
+			}
+			page = *next
+		}
+	}
+}
+
+// collectPages returns all entries of the given iterator. It stops at the first error.
+func collectPages[T any](entries iter.Seq2[T, error]) ([]T, error) {
+	var result []T
+	for entry, err := range entries {
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+// ListMeta is provided by list endpoints which return their results in pages.
+type ListMeta struct {
+	Pagination Pagination `json:"pagination"`
+}
+
+// Pagination describes the page of a list response. Next is nil on the last page.
+//
+//nolint:tagliatelle // This is an externally controlled data type.
+type Pagination struct {
+	Page    int  `json:"page"`
+	Next    *int `json:"next"`
+	Prev    *int `json:"prev"`
+	Pages   int  `json:"pages"`
+	PerPage int  `json:"per_page"`
+	Total   int  `json:"total"`
+}
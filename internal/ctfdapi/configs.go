@@ -0,0 +1,73 @@
+package ctfdapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
+	"path"
+)
+
+const (
+	configsPath = "/api/v1/configs"
+)
+
+type Config struct {
+	Id    int     `json:"id,omitempty"`
+	Key   string  `json:"key"`
+	Value *string `json:"value"`
+}
+
+func (c *Client) ListConfigs(ctx context.Context) ([]Config, error) {
+	return collectPages(c.IterateConfigs(ctx))
+}
+
+// IterateConfigs returns an iterator over all configs. The pages are fetched while iterating.
+func (c *Client) IterateConfigs(ctx context.Context) iter.Seq2[Config, error] {
+	return iteratePages[Config](ctx, c, configsPath, nil)
+}
+
+type GetConfigResponse struct {
+	Success bool   `json:"success"`
+	Data    Config `json:"data"`
+}
+
+func (c *Client) GetConfig(ctx context.Context, key string) (Config, error) {
+	data, err := c.sendGetRequest(ctx, path.Join(configsPath, key), nil)
+	if err != nil {
+		return Config{}, err
+	}
+
+	var response GetConfigResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return Config{}, err
+	}
+
+	if !response.Success {
+		return response.Data, errors.New("the API request did not succeed")
+	}
+	return response.Data, nil
+}
+
+type UpdateConfigsResponse struct {
+	Success bool `json:"success"`
+}
+
+// UpdateConfigs sets all given config keys to the given values in a single request. Keys which are not provided are
+// left unchanged. A nil value clears the config key.
+func (c *Client) UpdateConfigs(ctx context.Context, configs map[string]*string) error {
+	data, err := c.sendPatchRequest(ctx, configsPath, configs)
+	if err != nil {
+		return err
+	}
+
+	var response UpdateConfigsResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return err
+	}
+
+	if !response.Success {
+		return errors.New("the API request did not succeed")
+	}
+	return nil
+}
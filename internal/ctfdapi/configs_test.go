@@ -0,0 +1,43 @@
+package ctfdapi_test
+
+import (
+	"k8s.io/utils/ptr"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/backbone81/ctf-ui-operator/internal/ctfdapi"
+)
+
+var _ = Describe("Configs", func() {
+	var ctfdClient *ctfdapi.Client
+
+	BeforeEach(func(ctx SpecContext) {
+		var err error
+		ctfdClient, err = ctfdapi.NewClient(endpointUrl, accessToken)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should list the configs", func(ctx SpecContext) {
+		configs, err := ctfdClient.ListConfigs(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(configs).To(ContainElement(HaveField("Key", "ctf_name")))
+	})
+
+	It("should get an existing config", func(ctx SpecContext) {
+		config, err := ctfdClient.GetConfig(ctx, "ctf_name")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(config.Value).To(HaveValue(Equal(GetDefaultSetupRequest().CTFName)))
+	})
+
+	It("should update configs", func(ctx SpecContext) {
+		modifiedDescription := "This is a modified test CTF."
+		Expect(ctfdClient.UpdateConfigs(ctx, map[string]*string{
+			"ctf_description": ptr.To(modifiedDescription),
+		})).To(Succeed())
+
+		config, err := ctfdClient.GetConfig(ctx, "ctf_description")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(config.Value).To(HaveValue(Equal(modifiedDescription)))
+	})
+})
@@ -14,9 +14,20 @@ type Client struct {
 	client      *http.Client
 	accessToken string
 	csrfToken   string
+	retryPolicy RetryPolicy
 }
 
-func NewClient(baseUrl string, accessToken string) (*Client, error) {
+// ClientOption is an option for configuring the client.
+type ClientOption func(client *Client)
+
+// WithRetryPolicy configures the retry policy for idempotent requests. DefaultRetryPolicy is used when not provided.
+func WithRetryPolicy(retryPolicy RetryPolicy) ClientOption {
+	return func(client *Client) {
+		client.retryPolicy = retryPolicy
+	}
+}
+
+func NewClient(baseUrl string, accessToken string, options ...ClientOption) (*Client, error) {
 	parsedBaseUrl, err := url.Parse(baseUrl)
 	if err != nil {
 		return nil, fmt.Errorf("parsing base URL: %w", err)
@@ -37,11 +48,16 @@ func NewClient(baseUrl string, accessToken string) (*Client, error) {
 			return http.ErrUseLastResponse
 		},
 	}
-	return &Client{
+	result := &Client{
 		baseUrl:     parsedBaseUrl,
 		client:      httpClient,
 		accessToken: accessToken,
-	}, nil
+		retryPolicy: DefaultRetryPolicy,
+	}
+	for _, option := range options {
+		option(result)
+	}
+	return result, nil
 }
 
 func (c *Client) getTargetUrl(urlPath string, queryParameter map[string]string) (string, error) {
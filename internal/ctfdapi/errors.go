@@ -0,0 +1,131 @@
+package ctfdapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// APIError is returned when CTFd answers a request with an unexpected status code.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Status is the HTTP status of the response like "404 NOT FOUND".
+	Status string
+
+	// Messages are the error messages CTFd provided in the response body. It is empty when the response body did not
+	// contain any error messages, like for HTML pages.
+	Messages []string
+
+	// RequestId identifies the request in the logs of the reverse proxy in front of CTFd. CTFd itself does not provide
+	// a request id, so it is empty when no reverse proxy sets the X-Request-Id header.
+	RequestId string
+}
+
+func (e *APIError) Error() string {
+	var builder strings.Builder
+	_, _ = fmt.Fprintf(&builder, "unexpected status code %d: %s", e.StatusCode, e.Status)
+	if len(e.Messages) != 0 {
+		_, _ = fmt.Fprintf(&builder, " (%s)", strings.Join(e.Messages, "; "))
+	}
+	if len(e.RequestId) != 0 {
+		_, _ = fmt.Fprintf(&builder, " [request id %s]", e.RequestId)
+	}
+	return builder.String()
+}
+
+// errorResponse is the response body CTFd sends on errors. Validation errors are reported in errors, either as a list
+// or as a map from the field name to the list of messages for that field. Errors raised by the web framework are
+// reported in message.
+type errorResponse struct {
+	Message string          `json:"message"`
+	Errors  json.RawMessage `json:"errors"`
+}
+
+// newAPIError creates a new APIError from the given response and the already read response body.
+func newAPIError(response *http.Response, responseData []byte) *APIError {
+	return &APIError{
+		StatusCode: response.StatusCode,
+		Status:     response.Status,
+		Messages:   parseErrorMessages(responseData),
+		RequestId:  response.Header.Get("X-Request-Id"),
+	}
+}
+
+// parseErrorMessages extracts the error messages from the response body. Response bodies which are not in the
+// format of errorResponse result in no messages.
+func parseErrorMessages(responseData []byte) []string {
+	var response errorResponse
+	if err := json.Unmarshal(responseData, &response); err != nil {
+		return nil
+	}
+
+	var messages []string
+	if len(response.Message) != 0 {
+		messages = append(messages, response.Message)
+	}
+
+	var errorList []string
+	if err := json.Unmarshal(response.Errors, &errorList); err == nil {
+		return append(messages, errorList...)
+	}
+
+	var errorMap map[string][]string
+	if err := json.Unmarshal(response.Errors, &errorMap); err == nil {
+		// We sort the fields to get the same error message every time.
+		for _, field := range slices.Sorted(maps.Keys(errorMap)) {
+			for _, message := range errorMap[field] {
+				messages = append(messages, field+": "+message)
+			}
+		}
+	}
+	return messages
+}
+
+// IsNotFound returns true if the error was caused by CTFd not finding the requested object. This is usually the case
+// when the object was already deleted.
+func IsNotFound(err error) bool {
+	return hasStatusCode(err, http.StatusNotFound)
+}
+
+// IgnoreNotFound returns nil if the error was caused by CTFd not finding the requested object. All other errors are
+// returned unchanged. This is helpful when deleting objects which might already be deleted.
+func IgnoreNotFound(err error) error {
+	if IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// IsUnauthorized returns true if the error was caused by missing or invalid credentials, like an expired access token.
+func IsUnauthorized(err error) bool {
+	return hasStatusCode(err, http.StatusUnauthorized)
+}
+
+// IsForbidden returns true if the error was caused by the credentials not being allowed to access the endpoint.
+func IsForbidden(err error) bool {
+	return hasStatusCode(err, http.StatusForbidden)
+}
+
+// IsServerError returns true if the error was caused by CTFd failing to process the request. This is usually the case
+// while the instance is restarting or overloaded.
+func IsServerError(err error) bool {
+	var apiError *APIError
+	if !errors.As(err, &apiError) {
+		return false
+	}
+	return apiError.StatusCode >= http.StatusInternalServerError
+}
+
+func hasStatusCode(err error, statusCode int) bool {
+	var apiError *APIError
+	if !errors.As(err, &apiError) {
+		return false
+	}
+	return apiError.StatusCode == statusCode
+}
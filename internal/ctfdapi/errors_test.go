@@ -0,0 +1,119 @@
+package ctfdapi_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/backbone81/ctf-ui-operator/internal/ctfdapi"
+)
+
+var _ = Describe("Errors", func() {
+	var (
+		server       *httptest.Server
+		handler      http.HandlerFunc
+		requestCount atomic.Int32
+		ctfdClient   *ctfdapi.Client
+	)
+
+	BeforeEach(func() {
+		requestCount.Store(0)
+		server = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			requestCount.Add(1)
+			handler(writer, request)
+		}))
+		var err error
+		ctfdClient, err = ctfdapi.NewClient(server.URL, accessToken, ctfdapi.WithRetryPolicy(ctfdapi.RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     time.Millisecond,
+		}))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should report not found errors", func(ctx SpecContext) {
+		handler = func(writer http.ResponseWriter, request *http.Request) {
+			writer.Header().Set("X-Request-Id", "abc123")
+			writer.WriteHeader(http.StatusNotFound)
+			_, _ = writer.Write([]byte(`{"message": "The requested URL was not found on the server."}`))
+		}
+
+		_, err := ctfdClient.GetHint(ctx, 1)
+		Expect(ctfdapi.IsNotFound(err)).To(BeTrue())
+		Expect(ctfdapi.IgnoreNotFound(err)).To(Succeed())
+		var apiError *ctfdapi.APIError
+		Expect(err).To(BeAssignableToTypeOf(apiError))
+		Expect(err).To(MatchError(ContainSubstring("The requested URL was not found on the server.")))
+		Expect(err).To(MatchError(ContainSubstring("abc123")))
+		Expect(requestCount.Load()).To(BeEquivalentTo(1))
+	})
+
+	It("should report validation errors", func(ctx SpecContext) {
+		handler = func(writer http.ResponseWriter, request *http.Request) {
+			writer.WriteHeader(http.StatusBadRequest)
+			_, _ = writer.Write([]byte(`{"success": false, "errors": {"name": ["Name is required"], "email": ["Email is invalid"]}}`))
+		}
+
+		_, err := ctfdClient.GetHint(ctx, 1)
+		Expect(ctfdapi.IsNotFound(err)).To(BeFalse())
+		Expect(ctfdapi.IgnoreNotFound(err)).To(HaveOccurred())
+		Expect(err).To(MatchError(ContainSubstring("email: Email is invalid; name: Name is required")))
+	})
+
+	It("should report unauthorized errors", func(ctx SpecContext) {
+		handler = func(writer http.ResponseWriter, request *http.Request) {
+			writer.WriteHeader(http.StatusUnauthorized)
+		}
+
+		_, err := ctfdClient.GetHint(ctx, 1)
+		Expect(ctfdapi.IsUnauthorized(err)).To(BeTrue())
+		Expect(ctfdapi.IsForbidden(err)).To(BeFalse())
+		Expect(requestCount.Load()).To(BeEquivalentTo(1))
+	})
+
+	It("should retry idempotent requests on server errors", func(ctx SpecContext) {
+		handler = func(writer http.ResponseWriter, request *http.Request) {
+			if requestCount.Load() < 3 {
+				writer.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = writer.Write([]byte(`{"success": true, "data": {"id": 1}}`))
+		}
+
+		hint, err := ctfdClient.GetHint(ctx, 1)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hint.Id).To(Equal(1))
+		Expect(requestCount.Load()).To(BeEquivalentTo(3))
+	})
+
+	It("should give up after the maximum number of attempts", func(ctx SpecContext) {
+		handler = func(writer http.ResponseWriter, request *http.Request) {
+			writer.WriteHeader(http.StatusBadGateway)
+		}
+
+		_, err := ctfdClient.GetHint(ctx, 1)
+		Expect(ctfdapi.IsServerError(err)).To(BeTrue())
+		Expect(requestCount.Load()).To(BeEquivalentTo(3))
+	})
+
+	It("should not retry requests which are not idempotent", func(ctx SpecContext) {
+		handler = func(writer http.ResponseWriter, request *http.Request) {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+		}
+
+		_, err := ctfdClient.CreateHint(ctx, ctfdapi.Hint{
+			ChallengeId: 1,
+			Content:     "This is a test hint.",
+		})
+		Expect(ctfdapi.IsServerError(err)).To(BeTrue())
+		Expect(requestCount.Load()).To(BeEquivalentTo(1))
+	})
+})
@@ -0,0 +1,15 @@
+package ctfdapi
+
+import (
+	"context"
+)
+
+const (
+	exportPath = "/admin/export"
+)
+
+// Export returns a full backup of the CTFd instance as a zip file. The backup can be imported into another CTFd
+// instance through the admin panel.
+func (c *Client) Export(ctx context.Context) ([]byte, error) {
+	return c.sendGetRequest(ctx, exportPath, nil)
+}
@@ -0,0 +1,30 @@
+package ctfdapi_test
+
+import (
+	"archive/zip"
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/backbone81/ctf-ui-operator/internal/ctfdapi"
+)
+
+var _ = Describe("Export", func() {
+	var ctfdClient *ctfdapi.Client
+
+	BeforeEach(func(ctx SpecContext) {
+		var err error
+		ctfdClient, err = ctfdapi.NewClient(endpointUrl, accessToken)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should export the instance as zip file", func(ctx SpecContext) {
+		data, err := ctfdClient.Export(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		zipReader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(zipReader.File).ToNot(BeEmpty())
+	})
+})
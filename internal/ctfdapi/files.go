@@ -0,0 +1,130 @@
+package ctfdapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"iter"
+	"path"
+	"strconv"
+)
+
+const (
+	filesPath = "/api/v1/files"
+)
+
+// These are the file types supported by CTFd.
+const (
+	FileTypeStandard  = "standard"
+	FileTypeChallenge = "challenge"
+)
+
+type File struct {
+	Id   int    `json:"id,omitempty"`
+	Type string `json:"type"`
+
+	// Location is the path of the file in the upload storage. The last path segment is the name of the file.
+	Location string `json:"location"`
+	Sha1sum  string `json:"sha1sum,omitempty"`
+}
+
+// Name returns the name of the file as shown to players.
+func (f File) Name() string {
+	return path.Base(f.Location)
+}
+
+func (c *Client) ListFiles(ctx context.Context) ([]File, error) {
+	return collectPages(c.IterateFiles(ctx))
+}
+
+// IterateFiles returns an iterator over all files. The pages are fetched while iterating.
+func (c *Client) IterateFiles(ctx context.Context) iter.Seq2[File, error] {
+	return iteratePages[File](ctx, c, filesPath, nil)
+}
+
+func (c *Client) ListFilesForChallenge(ctx context.Context, challengeId int) ([]File, error) {
+	return collectPages(c.IterateFilesForChallenge(ctx, challengeId))
+}
+
+// IterateFilesForChallenge returns an iterator over all files of the challenge. The pages are fetched while iterating.
+func (c *Client) IterateFilesForChallenge(ctx context.Context, challengeId int) iter.Seq2[File, error] {
+	return iteratePages[File](ctx, c, path.Join(challengesPath, strconv.Itoa(challengeId), "files"), nil)
+}
+
+type GetFileResponse struct {
+	Success bool `json:"success"`
+	Data    File `json:"data"`
+}
+
+func (c *Client) GetFile(ctx context.Context, id int) (File, error) {
+	data, err := c.sendGetRequest(ctx, path.Join(filesPath, strconv.Itoa(id)), nil)
+	if err != nil {
+		return File{}, err
+	}
+
+	var response GetFileResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return File{}, err
+	}
+
+	if !response.Success {
+		return response.Data, errors.New("the API request did not succeed")
+	}
+	return response.Data, nil
+}
+
+type UploadFileResponse struct {
+	Success bool   `json:"success"`
+	Data    []File `json:"data"`
+}
+
+// UploadFile uploads the content as a file with the given name. With a challenge id, the file is attached to that
+// challenge. Without a challenge id, a standard file is uploaded, which can be referenced from pages.
+func (c *Client) UploadFile(ctx context.Context, challengeId int, name string, content io.Reader) (File, error) {
+	fields := map[string]string{
+		"type": FileTypeStandard,
+	}
+	if challengeId != 0 {
+		fields["type"] = FileTypeChallenge
+		fields["challenge_id"] = strconv.Itoa(challengeId)
+	}
+	data, err := c.sendMultipartPostRequest(ctx, filesPath, fields, "file", name, content)
+	if err != nil {
+		return File{}, err
+	}
+
+	var response UploadFileResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return File{}, err
+	}
+
+	if !response.Success {
+		return File{}, errors.New("the API request did not succeed")
+	}
+	if len(response.Data) != 1 {
+		return File{}, errors.New("the API request did not return the uploaded file")
+	}
+	return response.Data[0], nil
+}
+
+type DeleteFileResponse struct {
+	Success bool `json:"success"`
+}
+
+func (c *Client) DeleteFile(ctx context.Context, id int) error {
+	data, err := c.sendDeleteRequest(ctx, path.Join(filesPath, strconv.Itoa(id)), nil)
+	if err != nil {
+		return err
+	}
+
+	var response DeleteFileResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return err
+	}
+
+	if !response.Success {
+		return errors.New("the API request did not succeed")
+	}
+	return nil
+}
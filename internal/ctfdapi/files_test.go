@@ -0,0 +1,69 @@
+package ctfdapi_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/backbone81/ctf-ui-operator/internal/ctfdapi"
+)
+
+var _ = Describe("Files", func() {
+	var (
+		ctfdClient *ctfdapi.Client
+		challenge  ctfdapi.Challenge
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		var err error
+		ctfdClient, err = ctfdapi.NewClient(endpointUrl, accessToken)
+		Expect(err).ToNot(HaveOccurred())
+
+		challenge, err = ctfdClient.CreateChallenge(ctx, ctfdapi.Challenge{
+			Name: "Test Challenge",
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should upload a challenge file", func(ctx SpecContext) {
+		file, err := ctfdClient.UploadFile(ctx, challenge.Id, "test.txt", strings.NewReader("This is a test file."))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(file.Type).To(Equal(ctfdapi.FileTypeChallenge))
+		Expect(file.Name()).To(Equal("test.txt"))
+
+		files, err := ctfdClient.ListFilesForChallenge(ctx, challenge.Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(files).To(ConsistOf(HaveField("Id", file.Id)))
+	})
+
+	It("should upload a standard file", func(ctx SpecContext) {
+		file, err := ctfdClient.UploadFile(ctx, 0, "test.txt", strings.NewReader("This is a test file."))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(file.Type).To(Equal(ctfdapi.FileTypeStandard))
+
+		files, err := ctfdClient.ListFiles(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(files).To(ContainElement(HaveField("Id", file.Id)))
+	})
+
+	It("should get an existing file", func(ctx SpecContext) {
+		file, err := ctfdClient.UploadFile(ctx, challenge.Id, "test.txt", strings.NewReader("This is a test file."))
+		Expect(err).ToNot(HaveOccurred())
+
+		fileGet, err := ctfdClient.GetFile(ctx, file.Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fileGet.Location).To(Equal(file.Location))
+	})
+
+	It("should delete a file", func(ctx SpecContext) {
+		file, err := ctfdClient.UploadFile(ctx, challenge.Id, "test.txt", strings.NewReader("This is a test file."))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ctfdClient.DeleteFile(ctx, file.Id)).To(Succeed())
+
+		files, err := ctfdClient.ListFilesForChallenge(ctx, challenge.Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(files).To(BeEmpty())
+	})
+})
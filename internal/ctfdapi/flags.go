@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"iter"
 	"path"
 	"strconv"
 )
@@ -23,43 +24,22 @@ type Flag struct {
 	Data        string `json:"data"`
 }
 
-type ListFlagsResponse struct {
-	Success bool   `json:"success"`
-	Data    []Flag `json:"data"`
-}
-
 func (c *Client) ListFlags(ctx context.Context) ([]Flag, error) {
-	data, err := c.sendGetRequest(ctx, flagsPath, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var response ListFlagsResponse
-	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, err
-	}
+	return collectPages(c.IterateFlags(ctx))
+}
 
-	if !response.Success {
-		return response.Data, errors.New("the API request did not succeed")
-	}
-	return response.Data, nil
+// IterateFlags returns an iterator over all flags. The pages are fetched while iterating.
+func (c *Client) IterateFlags(ctx context.Context) iter.Seq2[Flag, error] {
+	return iteratePages[Flag](ctx, c, flagsPath, nil)
 }
 
 func (c *Client) ListFlagsForChallenge(ctx context.Context, challengeId int) ([]Flag, error) {
-	data, err := c.sendGetRequest(ctx, flagsPath, map[string]string{"challenge_id": strconv.Itoa(challengeId)})
-	if err != nil {
-		return nil, err
-	}
-
-	var response ListFlagsResponse
-	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, err
-	}
+	return collectPages(c.IterateFlagsForChallenge(ctx, challengeId))
+}
 
-	if !response.Success {
-		return response.Data, errors.New("the API request did not succeed")
-	}
-	return response.Data, nil
+// IterateFlagsForChallenge returns an iterator over all flags of the challenge. The pages are fetched while iterating.
+func (c *Client) IterateFlagsForChallenge(ctx context.Context, challengeId int) iter.Seq2[Flag, error] {
+	return iteratePages[Flag](ctx, c, flagsPath, map[string]string{"challenge_id": strconv.Itoa(challengeId)})
 }
 
 type CreateFlagResponse struct {
@@ -97,7 +77,7 @@ type DeleteFlagResponse struct {
 }
 
 func (c *Client) DeleteFlag(ctx context.Context, id int) error {
-	data, err := c.sendDeleteRequest(ctx, path.Join(flagsPath, strconv.Itoa(id)))
+	data, err := c.sendDeleteRequest(ctx, path.Join(flagsPath, strconv.Itoa(id)), nil)
 	if err != nil {
 		return err
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"iter"
 	"path"
 	"strconv"
 )
@@ -12,6 +13,9 @@ const (
 	hintsPath = "/api/v1/hints"
 )
 
+// HintTypeStandard is the only hint type supported by CTFd.
+const HintTypeStandard = "standard"
+
 //nolint:tagliatelle // This is an externally controlled data type.
 type Hint struct {
 	// Id is the unique id of the hint. This field needs to be configured as omitempty. Otherwise, a create call
@@ -22,47 +26,35 @@ type Hint struct {
 	ChallengeId int    `json:"challenge_id"`
 	Content     string `json:"content"`
 	Cost        int    `json:"cost"`
+
+	// Requirements are only provided by the get endpoint. They are omitted on create and update when not set, which
+	// leaves the requirements in CTFd unchanged.
+	Requirements *HintRequirements `json:"requirements,omitempty"`
 }
 
-type ListHintsResponse struct {
-	Success bool   `json:"success"`
-	Data    []Hint `json:"data"`
+// HintRequirements describe which other hints need to be unlocked before a hint can be unlocked.
+type HintRequirements struct {
+	Prerequisites []int `json:"prerequisites"`
 }
 
 func (c *Client) ListHintsForChallenge(ctx context.Context, challengeId int) ([]Hint, error) {
-	data, err := c.sendGetRequest(ctx, hintsPath, map[string]string{
+	return collectPages(c.IterateHintsForChallenge(ctx, challengeId))
+}
+
+// IterateHintsForChallenge returns an iterator over all hints of the challenge. The pages are fetched while iterating.
+func (c *Client) IterateHintsForChallenge(ctx context.Context, challengeId int) iter.Seq2[Hint, error] {
+	return iteratePages[Hint](ctx, c, hintsPath, map[string]string{
 		"challenge_id": strconv.Itoa(challengeId),
 	})
-	if err != nil {
-		return nil, err
-	}
-
-	var response ListHintsResponse
-	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, err
-	}
-
-	if !response.Success {
-		return response.Data, errors.New("the API request did not succeed")
-	}
-	return response.Data, nil
 }
 
 func (c *Client) ListHints(ctx context.Context) ([]Hint, error) {
-	data, err := c.sendGetRequest(ctx, hintsPath, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var response ListHintsResponse
-	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, err
-	}
+	return collectPages(c.IterateHints(ctx))
+}
 
-	if !response.Success {
-		return response.Data, errors.New("the API request did not succeed")
-	}
-	return response.Data, nil
+// IterateHints returns an iterator over all hints. The pages are fetched while iterating.
+func (c *Client) IterateHints(ctx context.Context) iter.Seq2[Hint, error] {
+	return iteratePages[Hint](ctx, c, hintsPath, nil)
 }
 
 type CreateHintResponse struct {
@@ -100,7 +92,7 @@ type DeleteHintResponse struct {
 }
 
 func (c *Client) DeleteHint(ctx context.Context, id int) error {
-	data, err := c.sendDeleteRequest(ctx, path.Join(hintsPath, strconv.Itoa(id)))
+	data, err := c.sendDeleteRequest(ctx, path.Join(hintsPath, strconv.Itoa(id)), nil)
 	if err != nil {
 		return err
 	}
@@ -66,6 +66,28 @@ var _ = Describe("Hints", func() {
 		Expect(updatedHint.Content).To(Equal(modifiedContent))
 	})
 
+	It("should update the requirements of a hint", func(ctx SpecContext) {
+		firstHint, err := ctfdClient.CreateHint(ctx, ctfdapi.Hint{
+			ChallengeId: challenge.Id,
+			Content:     "This is the first test hint.",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		secondHint, err := ctfdClient.CreateHint(ctx, ctfdapi.Hint{
+			ChallengeId: challenge.Id,
+			Content:     "This is the second test hint.",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		secondHint.Requirements = &ctfdapi.HintRequirements{
+			Prerequisites: []int{firstHint.Id},
+		}
+		Expect(ctfdClient.UpdateHint(ctx, secondHint)).Error().ToNot(HaveOccurred())
+
+		hintGet, err := ctfdClient.GetHint(ctx, secondHint.Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hintGet.Requirements).To(HaveValue(HaveField("Prerequisites", ConsistOf(firstHint.Id))))
+	})
+
 	It("should delete a hint", func(ctx SpecContext) {
 		hint, err := ctfdClient.CreateHint(ctx, ctfdapi.Hint{
 			ChallengeId: challenge.Id,
@@ -82,4 +104,18 @@ var _ = Describe("Hints", func() {
 		Expect(err).ToNot(HaveOccurred())
 		Expect(afterHints).To(HaveLen(len(beforeHints) - 1))
 	})
+
+	It("should report an already deleted hint as not found", func(ctx SpecContext) {
+		hint, err := ctfdClient.CreateHint(ctx, ctfdapi.Hint{
+			ChallengeId: challenge.Id,
+			Content:     "This is a test hint.",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ctfdClient.DeleteHint(ctx, hint.Id)).To(Succeed())
+
+		_, err = ctfdClient.GetHint(ctx, hint.Id)
+		Expect(ctfdapi.IsNotFound(err)).To(BeTrue())
+		err = ctfdClient.DeleteHint(ctx, hint.Id)
+		Expect(ctfdapi.IsNotFound(err)).To(BeTrue())
+	})
 })
@@ -65,13 +65,13 @@ func (c *Client) loginSendForm(ctx context.Context, loginRequest LoginRequest, n
 	}
 	defer response.Body.Close() //nolint:errcheck
 
-	_, err = io.ReadAll(response.Body)
+	responseData, err := io.ReadAll(response.Body)
 	if err != nil {
 		return fmt.Errorf("reading response body: %w", err)
 	}
 
 	if response.StatusCode != http.StatusFound {
-		return fmt.Errorf("unexpected status code %d: %s", response.StatusCode, response.Status)
+		return newAPIError(response, responseData)
 	}
 	return nil
 }
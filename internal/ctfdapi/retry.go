@@ -0,0 +1,89 @@
+package ctfdapi
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy configures how often and how fast failed requests are retried. Only idempotent requests are retried, as
+// retrying a request like creating a challenge might create the challenge twice.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts for a single request including the first attempt. A value of one
+	// or less disables retries.
+	MaxAttempts int
+
+	// InitialBackoff is the backoff before the first retry. The backoff is doubled for every further retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the upper limit for the backoff between two attempts.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy is used by all clients which are not configured with a different retry policy. It is meant to
+// bridge short outages like a restarting instance without blocking the reconcile loop for too long.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 200 * time.Millisecond,
+	MaxBackoff:     2 * time.Second,
+}
+
+// NoRetryPolicy disables all retries.
+var NoRetryPolicy = RetryPolicy{
+	MaxAttempts: 1,
+}
+
+// backoff returns the time to wait before the given retry. The first retry is 1. The backoff is jittered between half
+// and the full backoff, so that clients failing at the same time do not retry at the same time.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	backoff := p.InitialBackoff
+	for range retry - 1 {
+		backoff *= 2
+		if backoff >= p.MaxBackoff {
+			break
+		}
+	}
+	backoff = min(backoff, p.MaxBackoff)
+	if backoff <= 0 {
+		return 0
+	}
+	return backoff/2 + rand.N(backoff/2+1) //nolint:gosec // The jitter does not need a secure random number generator.
+}
+
+// wait blocks for the backoff of the given retry. It returns early with an error when the context is done.
+func (p RetryPolicy) wait(ctx context.Context, retry int) error {
+	timer := time.NewTimer(p.backoff(retry))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isIdempotentMethod returns true for all HTTP methods which can safely be sent multiple times.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// isRetryableError returns true for errors which might go away when the request is sent again. These are errors on
+// the network level and errors where CTFd was not able to process the request at that time.
+func isRetryableError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var apiError *APIError
+	if errors.As(err, &apiError) {
+		return apiError.StatusCode == http.StatusTooManyRequests || IsServerError(apiError)
+	}
+	return true
+}
@@ -110,7 +110,7 @@ func (c *Client) SetupRequired(ctx context.Context) (bool, error) {
 	}
 	defer response.Body.Close() //nolint:errcheck
 
-	_, err = io.ReadAll(response.Body)
+	responseData, err := io.ReadAll(response.Body)
 	if err != nil {
 		return false, fmt.Errorf("reading response body: %w", err)
 	}
@@ -121,7 +121,7 @@ func (c *Client) SetupRequired(ctx context.Context) (bool, error) {
 	if response.StatusCode == http.StatusFound {
 		return false, nil
 	}
-	return false, fmt.Errorf("unexpected status code %d: %s", response.StatusCode, response.Status)
+	return false, newAPIError(response, responseData)
 }
 
 // Setup initializes the CTFd instance. This request is special, as there is no REST API endpoint for setup. Instead,
@@ -173,13 +173,13 @@ func (c *Client) setupSendForm(ctx context.Context, setupRequest SetupRequest, n
 	}
 	defer response.Body.Close() //nolint:errcheck
 
-	_, err = io.ReadAll(response.Body)
+	responseData, err := io.ReadAll(response.Body)
 	if err != nil {
 		return fmt.Errorf("reading response body: %w", err)
 	}
 
 	if response.StatusCode != http.StatusFound {
-		return fmt.Errorf("unexpected status code %d: %s", response.StatusCode, response.Status)
+		return newAPIError(response, responseData)
 	}
 	return nil
 }
@@ -0,0 +1,133 @@
+package ctfdapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
+	"path"
+	"strconv"
+)
+
+const (
+	tagsPath = "/api/v1/tags"
+)
+
+//nolint:tagliatelle // This is an externally controlled data type.
+type Tag struct {
+	// Id is the unique id of the tag. This field needs to be configured as omitempty. Otherwise, a create call
+	// will submit the Id to the API endpoint, which will break database constraints.
+	Id          int    `json:"id,omitempty"`
+	ChallengeId int    `json:"challenge_id"`
+	Value       string `json:"value"`
+}
+
+func (c *Client) ListTagsForChallenge(ctx context.Context, challengeId int) ([]Tag, error) {
+	return collectPages(c.IterateTagsForChallenge(ctx, challengeId))
+}
+
+// IterateTagsForChallenge returns an iterator over all tags of the challenge. The pages are fetched while iterating.
+func (c *Client) IterateTagsForChallenge(ctx context.Context, challengeId int) iter.Seq2[Tag, error] {
+	return iteratePages[Tag](ctx, c, tagsPath, map[string]string{
+		"challenge_id": strconv.Itoa(challengeId),
+	})
+}
+
+func (c *Client) ListTags(ctx context.Context) ([]Tag, error) {
+	return collectPages(c.IterateTags(ctx))
+}
+
+// IterateTags returns an iterator over all tags. The pages are fetched while iterating.
+func (c *Client) IterateTags(ctx context.Context) iter.Seq2[Tag, error] {
+	return iteratePages[Tag](ctx, c, tagsPath, nil)
+}
+
+type CreateTagResponse struct {
+	Success bool `json:"success"`
+	Data    Tag  `json:"data"`
+}
+
+func (c *Client) CreateTag(ctx context.Context, tag Tag) (Tag, error) {
+	// Creating a tag with a specific ID will sooner or later result in violated database constraints.
+	// To prevent that, we reset the tag id.
+	tag.Id = 0
+	data, err := c.sendPostRequest(ctx, tagsPath, tag)
+	if err != nil {
+		return Tag{}, err
+	}
+
+	var response CreateTagResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return Tag{}, err
+	}
+
+	if !response.Success {
+		return response.Data, errors.New("the API request did not succeed")
+	}
+	return response.Data, nil
+}
+
+type DeleteTagResponse struct {
+	Success bool `json:"success"`
+}
+
+func (c *Client) DeleteTag(ctx context.Context, id int) error {
+	data, err := c.sendDeleteRequest(ctx, path.Join(tagsPath, strconv.Itoa(id)), nil)
+	if err != nil {
+		return err
+	}
+
+	var response DeleteTagResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return err
+	}
+
+	if !response.Success {
+		return errors.New("the API request did not succeed")
+	}
+	return nil
+}
+
+type UpdateTagResponse struct {
+	Success bool `json:"success"`
+	Data    Tag  `json:"data"`
+}
+
+func (c *Client) UpdateTag(ctx context.Context, tag Tag) (Tag, error) {
+	data, err := c.sendPatchRequest(ctx, path.Join(tagsPath, strconv.Itoa(tag.Id)), tag)
+	if err != nil {
+		return Tag{}, err
+	}
+
+	var response UpdateTagResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return Tag{}, err
+	}
+
+	if !response.Success {
+		return response.Data, errors.New("the API request did not succeed")
+	}
+	return response.Data, nil
+}
+
+type GetTagResponse struct {
+	Success bool `json:"success"`
+	Data    Tag  `json:"data"`
+}
+
+func (c *Client) GetTag(ctx context.Context, id int) (Tag, error) {
+	data, err := c.sendGetRequest(ctx, path.Join(tagsPath, strconv.Itoa(id)), nil)
+	if err != nil {
+		return Tag{}, err
+	}
+
+	var response GetTagResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return Tag{}, err
+	}
+
+	if !response.Success {
+		return response.Data, errors.New("the API request did not succeed")
+	}
+	return response.Data, nil
+}
@@ -0,0 +1,96 @@
+package ctfdapi_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/backbone81/ctf-ui-operator/internal/ctfdapi"
+)
+
+var _ = Describe("Tags", func() {
+	var (
+		ctfdClient *ctfdapi.Client
+		challenge  ctfdapi.Challenge
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		var err error
+		ctfdClient, err = ctfdapi.NewClient(endpointUrl, accessToken)
+		Expect(err).ToNot(HaveOccurred())
+
+		challenge, err = ctfdClient.CreateChallenge(ctx, ctfdapi.Challenge{
+			Name: "Test Challenge",
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should create a new tag", func(ctx SpecContext) {
+		beforeTags, err := ctfdClient.ListTagsForChallenge(ctx, challenge.Id)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ctfdClient.CreateTag(ctx, ctfdapi.Tag{
+			ChallengeId: challenge.Id,
+			Value:       "web",
+		})).Error().ToNot(HaveOccurred())
+
+		afterTags, err := ctfdClient.ListTagsForChallenge(ctx, challenge.Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(afterTags).To(HaveLen(len(beforeTags) + 1))
+	})
+
+	It("should list all tags", func(ctx SpecContext) {
+		tag, err := ctfdClient.CreateTag(ctx, ctfdapi.Tag{
+			ChallengeId: challenge.Id,
+			Value:       "web",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		tags, err := ctfdClient.ListTags(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tags).To(ContainElement(tag))
+	})
+
+	It("should get an existing tag", func(ctx SpecContext) {
+		tag, err := ctfdClient.CreateTag(ctx, ctfdapi.Tag{
+			ChallengeId: challenge.Id,
+			Value:       "web",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		tagGet, err := ctfdClient.GetTag(ctx, tag.Id)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(tagGet).To(Equal(tag))
+	})
+
+	It("should update a tag", func(ctx SpecContext) {
+		tag, err := ctfdClient.CreateTag(ctx, ctfdapi.Tag{
+			ChallengeId: challenge.Id,
+			Value:       "web",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		tag.Value = "crypto"
+		updatedTag, err := ctfdClient.UpdateTag(ctx, tag)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(updatedTag.Value).To(Equal("crypto"))
+	})
+
+	It("should delete a tag", func(ctx SpecContext) {
+		tag, err := ctfdClient.CreateTag(ctx, ctfdapi.Tag{
+			ChallengeId: challenge.Id,
+			Value:       "web",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		beforeTags, err := ctfdClient.ListTagsForChallenge(ctx, challenge.Id)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ctfdClient.DeleteTag(ctx, tag.Id)).To(Succeed())
+
+		afterTags, err := ctfdClient.ListTagsForChallenge(ctx, challenge.Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(afterTags).To(HaveLen(len(beforeTags) - 1))
+	})
+})
@@ -0,0 +1,243 @@
+package ctfdapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
+	"path"
+	"strconv"
+)
+
+const (
+	teamsPath = "/api/v1/teams"
+)
+
+//nolint:tagliatelle // This is an externally controlled data type.
+type Team struct {
+	// Id is the unique id of the team. This field needs to be configured as omitempty. Otherwise, a create call
+	// will submit the Id to the API endpoint, which will break database constraints.
+	Id    int    `json:"id,omitempty"`
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+
+	// Password is never returned by CTFd. It is omitted on update when not set, which leaves the password unchanged.
+	Password string `json:"password,omitempty"`
+
+	Website     string `json:"website,omitempty"`
+	Affiliation string `json:"affiliation,omitempty"`
+	Country     string `json:"country,omitempty"`
+	Hidden      bool   `json:"hidden"`
+	Banned      bool   `json:"banned"`
+
+	// CaptainId needs to reference a member of the team. It is omitted when not set, which leaves the captain
+	// unchanged.
+	CaptainId *int `json:"captain_id,omitempty"`
+
+	// BracketId is only supported by CTFd 3.7 and later. It is omitted when not set, which leaves the bracket unchanged.
+	BracketId *int `json:"bracket_id,omitempty"`
+}
+
+// ListTeams returns all teams including hidden and banned teams.
+func (c *Client) ListTeams(ctx context.Context) ([]Team, error) {
+	return collectPages(c.IterateTeams(ctx))
+}
+
+// IterateTeams returns an iterator over all teams including hidden and banned teams. The pages are fetched while
+// iterating.
+func (c *Client) IterateTeams(ctx context.Context) iter.Seq2[Team, error] {
+	return iteratePages[Team](ctx, c, teamsPath, map[string]string{"view": "admin"})
+}
+
+// ListTeamsPage returns a single page of teams including hidden and banned teams. The first page is 1.
+func (c *Client) ListTeamsPage(ctx context.Context, page int) (ListResponse[Team], error) {
+	return getPage[Team](ctx, c, teamsPath, map[string]string{"view": "admin"}, page)
+}
+
+type CreateTeamResponse struct {
+	Success bool `json:"success"`
+	Data    Team `json:"data"`
+}
+
+// CreateTeam creates a new team without any members. A captain can only be assigned after the captain joined the
+// team.
+func (c *Client) CreateTeam(ctx context.Context, team Team) (Team, error) {
+	// Creating a team with a specific ID will sooner or later result in violated database constraints.
+	// To prevent that, we reset the team id.
+	team.Id = 0
+	team.CaptainId = nil
+	data, err := c.sendPostRequest(ctx, teamsPath, team)
+	if err != nil {
+		return Team{}, err
+	}
+
+	var response CreateTeamResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return Team{}, err
+	}
+
+	if !response.Success {
+		return response.Data, errors.New("the API request did not succeed")
+	}
+	return response.Data, nil
+}
+
+type DeleteTeamResponse struct {
+	Success bool `json:"success"`
+}
+
+// DeleteTeam deletes the team. The members of the team are kept, but are not part of any team afterward.
+func (c *Client) DeleteTeam(ctx context.Context, id int) error {
+	data, err := c.sendDeleteRequest(ctx, path.Join(teamsPath, strconv.Itoa(id)), nil)
+	if err != nil {
+		return err
+	}
+
+	var response DeleteTeamResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return err
+	}
+
+	if !response.Success {
+		return errors.New("the API request did not succeed")
+	}
+	return nil
+}
+
+type UpdateTeamResponse struct {
+	Success bool `json:"success"`
+	Data    Team `json:"data"`
+}
+
+// UpdateTeam updates the given team. Banning and hiding a team is done by setting the corresponding fields.
+func (c *Client) UpdateTeam(ctx context.Context, team Team) (Team, error) {
+	data, err := c.sendPatchRequest(ctx, path.Join(teamsPath, strconv.Itoa(team.Id)), team)
+	if err != nil {
+		return Team{}, err
+	}
+
+	var response UpdateTeamResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return Team{}, err
+	}
+
+	if !response.Success {
+		return response.Data, errors.New("the API request did not succeed")
+	}
+	return response.Data, nil
+}
+
+// SetTeamCaptain makes the given user the captain of the team. The user needs to be a member of the team. Only the
+// captain is sent to CTFd, so that all other fields of the team are left unchanged.
+func (c *Client) SetTeamCaptain(ctx context.Context, teamId int, userId int) (Team, error) {
+	data, err := c.sendPatchRequest(ctx, path.Join(teamsPath, strconv.Itoa(teamId)), map[string]int{
+		"captain_id": userId,
+	})
+	if err != nil {
+		return Team{}, err
+	}
+
+	var response UpdateTeamResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return Team{}, err
+	}
+
+	if !response.Success {
+		return response.Data, errors.New("the API request did not succeed")
+	}
+	return response.Data, nil
+}
+
+type GetTeamResponse struct {
+	Success bool `json:"success"`
+	Data    Team `json:"data"`
+}
+
+func (c *Client) GetTeam(ctx context.Context, id int) (Team, error) {
+	data, err := c.sendGetRequest(ctx, path.Join(teamsPath, strconv.Itoa(id)), nil)
+	if err != nil {
+		return Team{}, err
+	}
+
+	var response GetTeamResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return Team{}, err
+	}
+
+	if !response.Success {
+		return response.Data, errors.New("the API request did not succeed")
+	}
+	return response.Data, nil
+}
+
+// TeamMemberRequest identifies the user to add to or remove from a team.
+//
+//nolint:tagliatelle // This is an externally controlled data type.
+type TeamMemberRequest struct {
+	UserId int `json:"user_id"`
+}
+
+// TeamMembersResponse provides the ids of all users who are members of the team.
+type TeamMembersResponse struct {
+	Success bool  `json:"success"`
+	Data    []int `json:"data"`
+}
+
+// ListTeamMembers returns the ids of all users who are members of the team.
+func (c *Client) ListTeamMembers(ctx context.Context, teamId int) ([]int, error) {
+	data, err := c.sendGetRequest(ctx, path.Join(teamsPath, strconv.Itoa(teamId), "members"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response TeamMembersResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, err
+	}
+
+	if !response.Success {
+		return response.Data, errors.New("the API request did not succeed")
+	}
+	return response.Data, nil
+}
+
+// AddTeamMember adds the user to the team. The user must not be a member of any other team. It returns the ids of all
+// members of the team.
+func (c *Client) AddTeamMember(ctx context.Context, teamId int, userId int) ([]int, error) {
+	data, err := c.sendPostRequest(ctx, path.Join(teamsPath, strconv.Itoa(teamId), "members"), TeamMemberRequest{
+		UserId: userId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var response TeamMembersResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, err
+	}
+
+	if !response.Success {
+		return response.Data, errors.New("the API request did not succeed")
+	}
+	return response.Data, nil
+}
+
+// RemoveTeamMember removes the user from the team. It returns the ids of the remaining members of the team.
+func (c *Client) RemoveTeamMember(ctx context.Context, teamId int, userId int) ([]int, error) {
+	data, err := c.sendDeleteRequestWithPayload(ctx, path.Join(teamsPath, strconv.Itoa(teamId), "members"), TeamMemberRequest{
+		UserId: userId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var response TeamMembersResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, err
+	}
+
+	if !response.Success {
+		return response.Data, errors.New("the API request did not succeed")
+	}
+	return response.Data, nil
+}
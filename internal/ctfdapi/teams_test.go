@@ -0,0 +1,101 @@
+package ctfdapi_test
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/backbone81/ctf-ui-operator/internal/ctfdapi"
+)
+
+var _ = Describe("Teams", func() {
+	var ctfdClient *ctfdapi.Client
+
+	BeforeEach(func() {
+		var err error
+		ctfdClient, err = ctfdapi.NewClient(endpointUrl, accessToken)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should create a new team", func(ctx SpecContext) {
+		beforeTeams, err := ctfdClient.ListTeams(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ctfdClient.CreateTeam(ctx, NewTestTeam())).Error().ToNot(HaveOccurred())
+
+		afterTeams, err := ctfdClient.ListTeams(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(afterTeams).To(HaveLen(len(beforeTeams) + 1))
+	})
+
+	It("should get an existing team", func(ctx SpecContext) {
+		team, err := ctfdClient.CreateTeam(ctx, NewTestTeam())
+		Expect(err).ToNot(HaveOccurred())
+
+		teamGet, err := ctfdClient.GetTeam(ctx, team.Id)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(teamGet.Name).To(Equal(team.Name))
+	})
+
+	It("should ban and hide a team", func(ctx SpecContext) {
+		team, err := ctfdClient.CreateTeam(ctx, NewTestTeam())
+		Expect(err).ToNot(HaveOccurred())
+
+		team.Banned = true
+		team.Hidden = true
+		updatedTeam, err := ctfdClient.UpdateTeam(ctx, team)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(updatedTeam.Banned).To(BeTrue())
+		Expect(updatedTeam.Hidden).To(BeTrue())
+	})
+
+	It("should manage the members and the captain of a team", func(ctx SpecContext) {
+		team, err := ctfdClient.CreateTeam(ctx, NewTestTeam())
+		Expect(err).ToNot(HaveOccurred())
+		firstUser, err := ctfdClient.CreateUser(ctx, NewTestUser(), false)
+		Expect(err).ToNot(HaveOccurred())
+		secondUser, err := ctfdClient.CreateUser(ctx, NewTestUser(), false)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ctfdClient.AddTeamMember(ctx, team.Id, firstUser.Id)).To(ConsistOf(firstUser.Id))
+		Expect(ctfdClient.AddTeamMember(ctx, team.Id, secondUser.Id)).To(ConsistOf(firstUser.Id, secondUser.Id))
+		Expect(ctfdClient.ListTeamMembers(ctx, team.Id)).To(ConsistOf(firstUser.Id, secondUser.Id))
+
+		updatedTeam, err := ctfdClient.SetTeamCaptain(ctx, team.Id, secondUser.Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updatedTeam.CaptainId).To(HaveValue(Equal(secondUser.Id)))
+
+		Expect(ctfdClient.RemoveTeamMember(ctx, team.Id, firstUser.Id)).To(ConsistOf(secondUser.Id))
+		user, err := ctfdClient.GetUser(ctx, firstUser.Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(user.TeamId).To(BeNil())
+	})
+
+	It("should delete a team", func(ctx SpecContext) {
+		team, err := ctfdClient.CreateTeam(ctx, NewTestTeam())
+		Expect(err).ToNot(HaveOccurred())
+
+		beforeTeams, err := ctfdClient.ListTeams(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ctfdClient.DeleteTeam(ctx, team.Id)).To(Succeed())
+
+		afterTeams, err := ctfdClient.ListTeams(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(afterTeams).To(HaveLen(len(beforeTeams) - 1))
+	})
+})
+
+// NewTestTeam returns a team with a unique name, as CTFd does not allow for duplicates.
+func NewTestTeam() ctfdapi.Team {
+	name := fmt.Sprintf("team-%d", time.Now().UnixNano())
+	return ctfdapi.Team{
+		Name:     name,
+		Email:    name + "@ctfd.internal",
+		Password: "team123",
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"iter"
 	"path"
 	"strconv"
 	"time"
@@ -23,26 +24,13 @@ type Token struct {
 	Value       string    `json:"value"`
 }
 
-type ListTokensResponse struct {
-	Success bool    `json:"success"`
-	Data    []Token `json:"data"`
+func (c *Client) ListTokens(ctx context.Context) ([]Token, error) {
+	return collectPages(c.IterateTokens(ctx))
 }
 
-func (c *Client) ListTokens(ctx context.Context) (ListTokensResponse, error) {
-	data, err := c.sendGetRequest(ctx, tokensPath, nil)
-	if err != nil {
-		return ListTokensResponse{}, err
-	}
-
-	var response ListTokensResponse
-	if err := json.Unmarshal(data, &response); err != nil {
-		return ListTokensResponse{}, err
-	}
-
-	if !response.Success {
-		return response, errors.New("the API request did not succeed")
-	}
-	return response, nil
+// IterateTokens returns an iterator over all access tokens. The pages are fetched while iterating.
+func (c *Client) IterateTokens(ctx context.Context) iter.Seq2[Token, error] {
+	return iteratePages[Token](ctx, c, tokensPath, nil)
 }
 
 type CreateTokenRequest struct {
@@ -92,7 +80,7 @@ type DeleteTokenResponse struct {
 }
 
 func (c *Client) DeleteToken(ctx context.Context, id int) (DeleteTokenResponse, error) {
-	data, err := c.sendDeleteRequest(ctx, path.Join(tokensPath, strconv.Itoa(id)))
+	data, err := c.sendDeleteRequest(ctx, path.Join(tokensPath, strconv.Itoa(id)), nil)
 	if err != nil {
 		return DeleteTokenResponse{}, err
 	}
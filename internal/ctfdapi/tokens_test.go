@@ -51,7 +51,7 @@ var _ = Describe("Tokens", func() {
 		afterList, err := ctfdClient.ListTokens(ctx)
 		Expect(err).ToNot(HaveOccurred())
 
-		Expect(afterList.Data).To(HaveLen(len(beforeList.Data) + 1))
+		Expect(afterList).To(HaveLen(len(beforeList) + 1))
 	})
 
 	It("should correctly get access tokens", func(ctx SpecContext) {
@@ -77,7 +77,7 @@ var _ = Describe("Tokens", func() {
 		afterList, err := ctfdClient.ListTokens(ctx)
 		Expect(err).ToNot(HaveOccurred())
 
-		Expect(afterList.Data).To(HaveLen(len(beforeList.Data) - 1))
+		Expect(afterList).To(HaveLen(len(beforeList) - 1))
 
 		Expect(ctfdClient.GetToken(ctx, createTokenRequest.Data.Id)).Error().To(HaveOccurred())
 	})
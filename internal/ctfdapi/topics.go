@@ -0,0 +1,151 @@
+package ctfdapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
+	"path"
+	"strconv"
+)
+
+const (
+	topicsPath = "/api/v1/topics"
+)
+
+// Topic is a learning objective which can be assigned to multiple challenges.
+type Topic struct {
+	// Id is the unique id of the topic. This field needs to be configured as omitempty. Otherwise, a create call
+	// will submit the Id to the API endpoint, which will break database constraints.
+	Id    int    `json:"id,omitempty"`
+	Value string `json:"value"`
+}
+
+// ChallengeTopic is the assignment of a topic to a challenge.
+//
+//nolint:tagliatelle // This is an externally controlled data type.
+type ChallengeTopic struct {
+	// Id is the unique id of the assignment and not the id of the topic.
+	Id          int    `json:"id,omitempty"`
+	ChallengeId int    `json:"challenge_id"`
+	TopicId     int    `json:"topic_id"`
+	Value       string `json:"value,omitempty"`
+}
+
+func (c *Client) ListTopics(ctx context.Context) ([]Topic, error) {
+	return collectPages(c.IterateTopics(ctx))
+}
+
+// IterateTopics returns an iterator over all topics. The pages are fetched while iterating.
+func (c *Client) IterateTopics(ctx context.Context) iter.Seq2[Topic, error] {
+	return iteratePages[Topic](ctx, c, topicsPath, nil)
+}
+
+type GetTopicResponse struct {
+	Success bool  `json:"success"`
+	Data    Topic `json:"data"`
+}
+
+func (c *Client) GetTopic(ctx context.Context, id int) (Topic, error) {
+	data, err := c.sendGetRequest(ctx, path.Join(topicsPath, strconv.Itoa(id)), nil)
+	if err != nil {
+		return Topic{}, err
+	}
+
+	var response GetTopicResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return Topic{}, err
+	}
+
+	if !response.Success {
+		return response.Data, errors.New("the API request did not succeed")
+	}
+	return response.Data, nil
+}
+
+type DeleteTopicResponse struct {
+	Success bool `json:"success"`
+}
+
+// DeleteTopic removes the topic together with all of its assignments to challenges.
+func (c *Client) DeleteTopic(ctx context.Context, id int) error {
+	data, err := c.sendDeleteRequest(ctx, path.Join(topicsPath, strconv.Itoa(id)), nil)
+	if err != nil {
+		return err
+	}
+
+	var response DeleteTopicResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return err
+	}
+
+	if !response.Success {
+		return errors.New("the API request did not succeed")
+	}
+	return nil
+}
+
+func (c *Client) ListTopicsForChallenge(ctx context.Context, challengeId int) ([]ChallengeTopic, error) {
+	return collectPages(c.IterateTopicsForChallenge(ctx, challengeId))
+}
+
+// IterateTopicsForChallenge returns an iterator over all topics of the challenge. The pages are fetched while iterating.
+func (c *Client) IterateTopicsForChallenge(ctx context.Context, challengeId int) iter.Seq2[ChallengeTopic, error] {
+	return iteratePages[ChallengeTopic](ctx, c, path.Join(challengesPath, strconv.Itoa(challengeId), "topics"), nil)
+}
+
+//nolint:tagliatelle // This is an externally controlled data type.
+type CreateChallengeTopicRequest struct {
+	Value       string `json:"value"`
+	ChallengeId int    `json:"challenge_id"`
+	Type        string `json:"type"`
+}
+
+type CreateChallengeTopicResponse struct {
+	Success bool           `json:"success"`
+	Data    ChallengeTopic `json:"data"`
+}
+
+// CreateChallengeTopic assigns the topic with the given value to the challenge. The topic is created if it does not
+// exist yet.
+func (c *Client) CreateChallengeTopic(ctx context.Context, challengeId int, value string) (ChallengeTopic, error) {
+	data, err := c.sendPostRequest(ctx, topicsPath, CreateChallengeTopicRequest{
+		Value:       value,
+		ChallengeId: challengeId,
+		Type:        "challenge",
+	})
+	if err != nil {
+		return ChallengeTopic{}, err
+	}
+
+	var response CreateChallengeTopicResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return ChallengeTopic{}, err
+	}
+
+	if !response.Success {
+		return response.Data, errors.New("the API request did not succeed")
+	}
+	return response.Data, nil
+}
+
+// DeleteChallengeTopic removes the assignment of a topic to a challenge. The topic itself is kept.
+func (c *Client) DeleteChallengeTopic(ctx context.Context, id int) error {
+	data, err := c.sendDeleteRequest(ctx, topicsPath, map[string]string{
+		"type":      "challenge",
+		"target_id": strconv.Itoa(id),
+	})
+	if err != nil {
+		return err
+	}
+
+	var response DeleteTopicResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return err
+	}
+
+	if !response.Success {
+		return errors.New("the API request did not succeed")
+	}
+	return nil
+}
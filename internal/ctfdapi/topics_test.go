@@ -0,0 +1,71 @@
+package ctfdapi_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/backbone81/ctf-ui-operator/internal/ctfdapi"
+)
+
+var _ = Describe("Topics", func() {
+	var (
+		ctfdClient *ctfdapi.Client
+		challenge  ctfdapi.Challenge
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		var err error
+		ctfdClient, err = ctfdapi.NewClient(endpointUrl, accessToken)
+		Expect(err).ToNot(HaveOccurred())
+
+		challenge, err = ctfdClient.CreateChallenge(ctx, ctfdapi.Challenge{
+			Name: "Test Challenge",
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should assign a topic to a challenge", func(ctx SpecContext) {
+		Expect(ctfdClient.CreateChallengeTopic(ctx, challenge.Id, "SQL Injection")).Error().ToNot(HaveOccurred())
+
+		challengeTopics, err := ctfdClient.ListTopicsForChallenge(ctx, challenge.Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(challengeTopics).To(ContainElement(HaveField("Value", "SQL Injection")))
+
+		topics, err := ctfdClient.ListTopics(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(topics).To(ContainElement(HaveField("Value", "SQL Injection")))
+	})
+
+	It("should get an existing topic", func(ctx SpecContext) {
+		challengeTopic, err := ctfdClient.CreateChallengeTopic(ctx, challenge.Id, "Buffer Overflow")
+		Expect(err).ToNot(HaveOccurred())
+
+		topic, err := ctfdClient.GetTopic(ctx, challengeTopic.TopicId)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(topic.Value).To(Equal("Buffer Overflow"))
+	})
+
+	It("should remove a topic from a challenge", func(ctx SpecContext) {
+		challengeTopic, err := ctfdClient.CreateChallengeTopic(ctx, challenge.Id, "Path Traversal")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ctfdClient.DeleteChallengeTopic(ctx, challengeTopic.Id)).To(Succeed())
+
+		challengeTopics, err := ctfdClient.ListTopicsForChallenge(ctx, challenge.Id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(challengeTopics).ToNot(ContainElement(HaveField("Value", "Path Traversal")))
+
+		Expect(ctfdClient.GetTopic(ctx, challengeTopic.TopicId)).Error().ToNot(HaveOccurred())
+	})
+
+	It("should delete a topic", func(ctx SpecContext) {
+		challengeTopic, err := ctfdClient.CreateChallengeTopic(ctx, challenge.Id, "Race Condition")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ctfdClient.DeleteTopic(ctx, challengeTopic.TopicId)).To(Succeed())
+
+		topics, err := ctfdClient.ListTopics(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(topics).ToNot(ContainElement(HaveField("Value", "Race Condition")))
+	})
+})
@@ -0,0 +1,174 @@
+package ctfdapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"path"
+	"strconv"
+)
+
+const (
+	usersPath = "/api/v1/users"
+)
+
+// These are the user types supported by CTFd.
+const (
+	UserTypeUser  = "user"
+	UserTypeAdmin = "admin"
+)
+
+//nolint:tagliatelle // This is an externally controlled data type.
+type User struct {
+	// Id is the unique id of the user. This field needs to be configured as omitempty. Otherwise, a create call
+	// will submit the Id to the API endpoint, which will break database constraints.
+	Id    int    `json:"id,omitempty"`
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+	Type  string `json:"type,omitempty"`
+
+	// Password is never returned by CTFd. It is omitted on update when not set, which leaves the password unchanged.
+	Password string `json:"password,omitempty"`
+
+	Website     string `json:"website,omitempty"`
+	Affiliation string `json:"affiliation,omitempty"`
+	Country     string `json:"country,omitempty"`
+	Verified    bool   `json:"verified"`
+	Hidden      bool   `json:"hidden"`
+	Banned      bool   `json:"banned"`
+
+	// TeamId is managed through the team members endpoints and is ignored on create and update.
+	TeamId *int `json:"team_id,omitempty"`
+
+	// BracketId is only supported by CTFd 3.7 and later. It is omitted when not set, which leaves the bracket unchanged.
+	BracketId *int `json:"bracket_id,omitempty"`
+}
+
+// ListUsers returns all users including hidden and banned users.
+func (c *Client) ListUsers(ctx context.Context) ([]User, error) {
+	return collectPages(c.IterateUsers(ctx))
+}
+
+// IterateUsers returns an iterator over all users including hidden and banned users. The pages are fetched while
+// iterating.
+func (c *Client) IterateUsers(ctx context.Context) iter.Seq2[User, error] {
+	return iteratePages[User](ctx, c, usersPath, map[string]string{"view": "admin"})
+}
+
+// ListUsersPage returns a single page of users including hidden and banned users. The first page is 1.
+func (c *Client) ListUsersPage(ctx context.Context, page int) (ListResponse[User], error) {
+	return getPage[User](ctx, c, usersPath, map[string]string{"view": "admin"}, page)
+}
+
+type CreateUserResponse struct {
+	Success bool `json:"success"`
+	Data    User `json:"data"`
+}
+
+// CreateUser creates a new user. If notify is true, CTFd sends the credentials to the email address of the user.
+func (c *Client) CreateUser(ctx context.Context, user User, notify bool) (User, error) {
+	// Creating a user with a specific ID will sooner or later result in violated database constraints.
+	// To prevent that, we reset the user id.
+	user.Id = 0
+	user.TeamId = nil
+	if user.Type == "" {
+		user.Type = UserTypeUser
+	}
+	payloadData, err := json.Marshal(user)
+	if err != nil {
+		return User{}, fmt.Errorf("marshalling payload into JSON: %w", err)
+	}
+	var queryParameter map[string]string
+	if notify {
+		queryParameter = map[string]string{"notify": "true"}
+	}
+	request, err := c.prepareRequest(ctx, http.MethodPost, usersPath, queryParameter, payloadData)
+	if err != nil {
+		return User{}, err
+	}
+	data, err := c.executeRequest(request)
+	if err != nil {
+		return User{}, err
+	}
+
+	var response CreateUserResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return User{}, err
+	}
+
+	if !response.Success {
+		return response.Data, errors.New("the API request did not succeed")
+	}
+	return response.Data, nil
+}
+
+type DeleteUserResponse struct {
+	Success bool `json:"success"`
+}
+
+func (c *Client) DeleteUser(ctx context.Context, id int) error {
+	data, err := c.sendDeleteRequest(ctx, path.Join(usersPath, strconv.Itoa(id)), nil)
+	if err != nil {
+		return err
+	}
+
+	var response DeleteUserResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return err
+	}
+
+	if !response.Success {
+		return errors.New("the API request did not succeed")
+	}
+	return nil
+}
+
+type UpdateUserResponse struct {
+	Success bool `json:"success"`
+	Data    User `json:"data"`
+}
+
+// UpdateUser updates the given user. Banning and hiding a user is done by setting the corresponding fields.
+func (c *Client) UpdateUser(ctx context.Context, user User) (User, error) {
+	// The team of a user can only be changed through the team members endpoints.
+	user.TeamId = nil
+	data, err := c.sendPatchRequest(ctx, path.Join(usersPath, strconv.Itoa(user.Id)), user)
+	if err != nil {
+		return User{}, err
+	}
+
+	var response UpdateUserResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return User{}, err
+	}
+
+	if !response.Success {
+		return response.Data, errors.New("the API request did not succeed")
+	}
+	return response.Data, nil
+}
+
+type GetUserResponse struct {
+	Success bool `json:"success"`
+	Data    User `json:"data"`
+}
+
+func (c *Client) GetUser(ctx context.Context, id int) (User, error) {
+	data, err := c.sendGetRequest(ctx, path.Join(usersPath, strconv.Itoa(id)), nil)
+	if err != nil {
+		return User{}, err
+	}
+
+	var response GetUserResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return User{}, err
+	}
+
+	if !response.Success {
+		return response.Data, errors.New("the API request did not succeed")
+	}
+	return response.Data, nil
+}
@@ -0,0 +1,114 @@
+package ctfdapi_test
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/backbone81/ctf-ui-operator/internal/ctfdapi"
+)
+
+var _ = Describe("Users", func() {
+	var ctfdClient *ctfdapi.Client
+
+	BeforeEach(func() {
+		var err error
+		ctfdClient, err = ctfdapi.NewClient(endpointUrl, accessToken)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should create a new user", func(ctx SpecContext) {
+		beforeUsers, err := ctfdClient.ListUsers(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ctfdClient.CreateUser(ctx, NewTestUser(), false)).Error().ToNot(HaveOccurred())
+
+		afterUsers, err := ctfdClient.ListUsers(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(afterUsers).To(HaveLen(len(beforeUsers) + 1))
+	})
+
+	It("should get an existing user", func(ctx SpecContext) {
+		user, err := ctfdClient.CreateUser(ctx, NewTestUser(), false)
+		Expect(err).ToNot(HaveOccurred())
+
+		userGet, err := ctfdClient.GetUser(ctx, user.Id)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(userGet.Name).To(Equal(user.Name))
+		Expect(userGet.Email).To(Equal(user.Email))
+	})
+
+	It("should ban and hide a user", func(ctx SpecContext) {
+		user, err := ctfdClient.CreateUser(ctx, NewTestUser(), false)
+		Expect(err).ToNot(HaveOccurred())
+
+		user.Banned = true
+		user.Hidden = true
+		updatedUser, err := ctfdClient.UpdateUser(ctx, user)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(updatedUser.Banned).To(BeTrue())
+		Expect(updatedUser.Hidden).To(BeTrue())
+		users, err := ctfdClient.ListUsers(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(users).To(ContainElement(HaveField("Id", user.Id)))
+	})
+
+	It("should list users across several pages", func(ctx SpecContext) {
+		firstPage, err := ctfdClient.ListUsersPage(ctx, 1)
+		Expect(err).ToNot(HaveOccurred())
+		for range firstPage.Meta.Pagination.PerPage - len(firstPage.Data) + 1 {
+			Expect(ctfdClient.CreateUser(ctx, NewTestUser(), false)).Error().ToNot(HaveOccurred())
+		}
+
+		firstPage, err = ctfdClient.ListUsersPage(ctx, 1)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(firstPage.Meta.Pagination.Next).ToNot(BeNil())
+		users, err := ctfdClient.ListUsers(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(users).To(HaveLen(firstPage.Meta.Pagination.Total))
+	})
+
+	It("should stop iterating users early", func(ctx SpecContext) {
+		Expect(ctfdClient.CreateUser(ctx, NewTestUser(), false)).Error().ToNot(HaveOccurred())
+		Expect(ctfdClient.CreateUser(ctx, NewTestUser(), false)).Error().ToNot(HaveOccurred())
+
+		var users []ctfdapi.User
+		for user, err := range ctfdClient.IterateUsers(ctx) {
+			Expect(err).ToNot(HaveOccurred())
+			users = append(users, user)
+			if len(users) == 1 {
+				break
+			}
+		}
+		Expect(users).To(HaveLen(1))
+	})
+
+	It("should delete a user", func(ctx SpecContext) {
+		user, err := ctfdClient.CreateUser(ctx, NewTestUser(), false)
+		Expect(err).ToNot(HaveOccurred())
+
+		beforeUsers, err := ctfdClient.ListUsers(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ctfdClient.DeleteUser(ctx, user.Id)).To(Succeed())
+
+		afterUsers, err := ctfdClient.ListUsers(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(afterUsers).To(HaveLen(len(beforeUsers) - 1))
+	})
+})
+
+// NewTestUser returns a user with a unique name and email address, as CTFd does not allow for duplicates.
+func NewTestUser() ctfdapi.User {
+	name := fmt.Sprintf("user-%d", time.Now().UnixNano())
+	return ctfdapi.User{
+		Name:     name,
+		Email:    name + "@ctfd.internal",
+		Password: "user123",
+		Verified: true,
+	}
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ObjectWithConditions is a Kubernetes object which reports conditions in its status.
+type ObjectWithConditions interface {
+	client.Object
+	GetConditions() *[]metav1.Condition
+}
+
+// SetCondition sets the condition of the given type on the object. The status of the object is only updated when the
+// condition actually changed. The message should therefore not contain details which change on every call like error
+// messages. Those belong into events and logs.
+func (r *DefaultSubReconciler) SetCondition(
+	ctx context.Context,
+	obj ObjectWithConditions,
+	conditionType string,
+	status metav1.ConditionStatus,
+	reason string,
+	message string,
+) error {
+	if !meta.SetStatusCondition(obj.GetConditions(), metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: obj.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	}) {
+		return nil
+	}
+	return r.client.Status().Update(ctx, obj)
+}
+
+// RemoveCondition removes the condition of the given type from the object. The status of the object is only updated
+// when the condition was present.
+func (r *DefaultSubReconciler) RemoveCondition(ctx context.Context, obj ObjectWithConditions, conditionType string) error {
+	if !meta.RemoveStatusCondition(obj.GetConditions(), conditionType) {
+		return nil
+	}
+	return r.client.Status().Update(ctx, obj)
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -8,12 +9,14 @@ import (
 // DefaultSubReconciler provides fields and methods which are needed for all sub reconcilers. This struct is intended
 // to be embedded into the concrete sub reconciler.
 type DefaultSubReconciler struct {
-	client client.Client
+	client   client.Client
+	recorder record.EventRecorder
 }
 
-func NewDefaultSubReconciler(client client.Client) DefaultSubReconciler {
+func NewDefaultSubReconciler(client client.Client, recorder record.EventRecorder) DefaultSubReconciler {
 	return DefaultSubReconciler{
-		client: client,
+		client:   client,
+		recorder: recorder,
 	}
 }
 
@@ -21,6 +24,15 @@ func (r *DefaultSubReconciler) GetClient() client.Client {
 	return r.client
 }
 
+func (r *DefaultSubReconciler) GetRecorder() record.EventRecorder {
+	return r.recorder
+}
+
+// Blocking returns true, as most sub-reconcilers depend on the work of the sub-reconcilers before them.
+func (r *DefaultSubReconciler) Blocking() bool {
+	return true
+}
+
 func (r *DefaultSubReconciler) SetupWithManager(ctrlBuilder *builder.Builder) *builder.Builder {
 	return ctrlBuilder
 }
@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetDeploymentCondition returns a condition of the given type which reflects the readiness of the given deployment.
+// A nil deployment is treated as a deployment which does not exist yet.
+func GetDeploymentCondition(conditionType string, generation int64, deployment *appsv1.Deployment) metav1.Condition {
+	result := metav1.Condition{
+		Type:               conditionType,
+		ObservedGeneration: generation,
+	}
+	switch {
+	case deployment == nil:
+		result.Status = metav1.ConditionFalse
+		result.Reason = "DeploymentNotFound"
+		result.Message = "The deployment does not exist yet."
+	case deployment.Status.ReadyReplicas > 0 && deployment.Status.Replicas == deployment.Status.ReadyReplicas:
+		result.Status = metav1.ConditionTrue
+		result.Reason = "DeploymentReady"
+		result.Message = fmt.Sprintf("%d of %d replicas are ready.", deployment.Status.ReadyReplicas, deployment.Status.Replicas)
+	default:
+		result.Status = metav1.ConditionFalse
+		result.Reason = "DeploymentNotReady"
+		result.Message = fmt.Sprintf("%d of %d replicas are ready.", deployment.Status.ReadyReplicas, deployment.Status.Replicas)
+	}
+	return result
+}
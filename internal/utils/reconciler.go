@@ -2,27 +2,42 @@ package utils
 
 import (
 	"context"
+	"errors"
 	"reflect"
+	"slices"
 
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
+// FinalizerName is the finalizer which is put on resources with sub-reconcilers implementing the SubFinalizer
+// interface. It prevents the resource from being removed before all finalizers are done.
+const FinalizerName = "ui.ctf.backbone81/finalizer"
+
 // Reconciler is a generalization of a top level reconciler. The type parameter should be a pointer to the kubernetes
 // data type this reconciler will reconcile. Every reconcile event is then forwarded to all sub reconcilers.
 type Reconciler[T client.Object] struct {
 	client         client.Client
+	recorder       record.EventRecorder
 	subReconcilers []SubReconciler[T]
 	newObj         func() T
 }
 
-// NewReconciler creates a new reconciler instance. The reconciler is initialized with the given client and applies
-// the provided options to the reconciler.
-func NewReconciler[T client.Object](client client.Client, newObj func() T, options ...ReconcilerOption[T]) *Reconciler[T] {
+// NewReconciler creates a new reconciler instance. The reconciler is initialized with the given client and event
+// recorder and applies the provided options to the reconciler.
+func NewReconciler[T client.Object](
+	client client.Client,
+	recorder record.EventRecorder,
+	newObj func() T,
+	options ...ReconcilerOption[T],
+) *Reconciler[T] {
 	result := &Reconciler[T]{
-		client: client,
-		newObj: newObj,
+		client:   client,
+		recorder: recorder,
+		newObj:   newObj,
 	}
 	for _, option := range options {
 		option(result)
@@ -32,6 +47,10 @@ func NewReconciler[T client.Object](client client.Client, newObj func() T, optio
 
 // SetupWithManager registers all enabled sub-reconcilers with the given manager.
 func (r *Reconciler[T]) SetupWithManager(mgr ctrl.Manager) error {
+	if err := setupIndexes(context.Background(), mgr.GetFieldIndexer(), r.subReconcilers); err != nil {
+		return err
+	}
+
 	ctrlBuilder := ctrl.NewControllerManagedBy(mgr).
 		For(r.newObj())
 	for _, subReconciler := range r.subReconcilers {
@@ -40,6 +59,20 @@ func (r *Reconciler[T]) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrlBuilder.Complete(r)
 }
 
+// setupIndexes registers the field indexes of all sub-reconcilers implementing the SubIndexer interface.
+func setupIndexes[T client.Object](ctx context.Context, indexer client.FieldIndexer, subReconcilers []SubReconciler[T]) error {
+	for _, subReconciler := range subReconcilers {
+		subIndexer, ok := subReconciler.(SubIndexer)
+		if !ok {
+			continue
+		}
+		if err := subIndexer.SetupIndexes(ctx, indexer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (r *Reconciler[T]) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	obj, err := r.getObject(ctx, req)
 	if err != nil {
@@ -50,15 +83,85 @@ func (r *Reconciler[T]) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 		return ctrl.Result{}, nil
 	}
 
-	for _, subReconciler := range r.subReconcilers {
-		result, err := subReconciler.Reconcile(ctx, obj)
+	if !obj.GetDeletionTimestamp().IsZero() {
+		return r.finalize(ctx, obj)
+	}
+
+	if r.hasSubFinalizers() && controllerutil.AddFinalizer(obj, FinalizerName) {
+		if err := r.client.Update(ctx, obj); err != nil {
+			return ctrl.Result{}, IgnoreConflict(err)
+		}
+	}
+
+	return reconcileSubReconcilers(ctx, obj, r.subReconcilers)
+}
+
+// reconcileSubReconcilers runs the given sub-reconcilers in order. Blocking sub-reconcilers stop the chain when they
+// fail or request a requeue, as the sub-reconcilers after them depend on their work. Independent sub-reconcilers do
+// not stop the chain. All errors are reported together.
+func reconcileSubReconcilers[T client.Object](ctx context.Context, obj T, subReconcilers []SubReconciler[T]) (ctrl.Result, error) {
+	var (
+		result ctrl.Result
+		errs   []error
+	)
+	for _, subReconciler := range subReconcilers {
+		subResult, err := subReconciler.Reconcile(ctx, obj)
+		result = mergeResults(result, subResult)
+		if err := IgnoreConflict(err); err != nil {
+			errs = append(errs, err)
+		}
+		if subReconciler.Blocking() && (err != nil || !subResult.IsZero()) {
+			break
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+// mergeResults combines two results into one which requeues as early as any of the given results.
+func mergeResults(a ctrl.Result, b ctrl.Result) ctrl.Result {
+	result := ctrl.Result{
+		Requeue:      a.Requeue || b.Requeue,
+		RequeueAfter: a.RequeueAfter,
+	}
+	if result.RequeueAfter == 0 || (b.RequeueAfter != 0 && b.RequeueAfter < result.RequeueAfter) {
+		result.RequeueAfter = b.RequeueAfter
+	}
+	return result
+}
+
+// finalize calls all sub-finalizers in reverse order of the sub-reconcilers. That way, everything is torn down in the
+// opposite order it was set up. The finalizer is removed from the resource only after all sub-finalizers succeeded.
+func (r *Reconciler[T]) finalize(ctx context.Context, obj T) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(obj, FinalizerName) {
+		// Nothing to do for us. The resource is waiting for some other finalizer to be removed.
+		return ctrl.Result{}, nil
+	}
+
+	for _, subReconciler := range slices.Backward(r.subReconcilers) {
+		subFinalizer, ok := subReconciler.(SubFinalizer[T])
+		if !ok {
+			continue
+		}
+		result, err := subFinalizer.Finalize(ctx, obj)
 		if err != nil || !result.IsZero() {
 			return result, IgnoreConflict(err)
 		}
 	}
+
+	controllerutil.RemoveFinalizer(obj, FinalizerName)
+	if err := r.client.Update(ctx, obj); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(IgnoreConflict(err))
+	}
 	return ctrl.Result{}, nil
 }
 
+func (r *Reconciler[T]) hasSubFinalizers() bool {
+	return slices.ContainsFunc(r.subReconcilers, func(subReconciler SubReconciler[T]) bool {
+		_, ok := subReconciler.(SubFinalizer[T])
+		return ok
+	})
+}
+
 func (r *Reconciler[T]) getObject(ctx context.Context, req ctrl.Request) (T, error) {
 	result := r.newObj()
 	if err := r.client.Get(ctx, req.NamespacedName, result); err != nil {
@@ -76,10 +179,30 @@ func (r *Reconciler[T]) GetClient() client.Client {
 	return r.client
 }
 
+func (r *Reconciler[T]) GetRecorder() record.EventRecorder {
+	return r.recorder
+}
+
 // SubReconciler is the interface all sub-reconcilers need to implement.
 type SubReconciler[T client.Object] interface {
 	Reconcile(ctx context.Context, obj T) (ctrl.Result, error)
 	SetupWithManager(builder *builder.Builder) *builder.Builder
+
+	// Blocking returns true when the sub-reconcilers after this one must not run when this one fails or requests a
+	// requeue. Independent sub-reconcilers return false.
+	Blocking() bool
+}
+
+// SubFinalizer is an optional interface sub-reconcilers can implement when they need to clean up before the resource
+// is removed. Finalize is called repeatedly until it returns without error and with a zero result.
+type SubFinalizer[T client.Object] interface {
+	Finalize(ctx context.Context, obj T) (ctrl.Result, error)
+}
+
+// SubIndexer is an optional interface sub-reconcilers can implement when they need field indexes on the cache. The
+// indexes are registered before the sub-reconciler is set up with the manager.
+type SubIndexer interface {
+	SetupIndexes(ctx context.Context, indexer client.FieldIndexer) error
 }
 
 // ReconcilerOption is an option which can be applied to the reconciler.
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SubReconcilerGroup runs sub-reconcilers which are independent of each other as one blocking sub-reconciler. All
+// sub-reconcilers of the group run even when some of them fail, but the sub-reconcilers after the group only run when
+// the whole group succeeded. Sub-finalizers are not supported within a group.
+type SubReconcilerGroup[T client.Object] struct {
+	subReconcilers []SubReconciler[T]
+}
+
+var (
+	_ SubReconciler[client.Object] = (*SubReconcilerGroup[client.Object])(nil)
+	_ SubIndexer                   = (*SubReconcilerGroup[client.Object])(nil)
+)
+
+func NewSubReconcilerGroup[T client.Object](subReconcilers ...SubReconciler[T]) *SubReconcilerGroup[T] {
+	return &SubReconcilerGroup[T]{
+		subReconcilers: subReconcilers,
+	}
+}
+
+func (r *SubReconcilerGroup[T]) Reconcile(ctx context.Context, obj T) (ctrl.Result, error) {
+	return reconcileSubReconcilers(ctx, obj, r.subReconcilers)
+}
+
+func (r *SubReconcilerGroup[T]) SetupWithManager(ctrlBuilder *builder.Builder) *builder.Builder {
+	for _, subReconciler := range r.subReconcilers {
+		ctrlBuilder = subReconciler.SetupWithManager(ctrlBuilder)
+	}
+	return ctrlBuilder
+}
+
+func (r *SubReconcilerGroup[T]) SetupIndexes(ctx context.Context, indexer client.FieldIndexer) error {
+	return setupIndexes(ctx, indexer, r.subReconcilers)
+}
+
+// Blocking returns true, as the sub-reconcilers after the group depend on the work of the whole group.
+func (r *SubReconcilerGroup[T]) Blocking() bool {
+	return true
+}
+
+// WithSubReconcilerGroup adds the sub-reconcilers of the given options to the reconciler as one SubReconcilerGroup.
+func WithSubReconcilerGroup[T client.Object](options ...ReconcilerOption[T]) ReconcilerOption[T] {
+	return func(reconciler *Reconciler[T]) {
+		group := &Reconciler[T]{
+			client:   reconciler.client,
+			recorder: reconciler.recorder,
+		}
+		for _, option := range options {
+			option(group)
+		}
+		reconciler.AppendSubReconciler(NewSubReconcilerGroup(group.subReconcilers...))
+	}
+}